@@ -4,6 +4,7 @@
 
 use App\Models\Task;
 use App\Services\RabbitMQService;
+use App\Services\TraceContext;
 use Illuminate\Bus\Queueable;
 use Illuminate\Contracts\Queue\ShouldQueue;
 use Illuminate\Foundation\Bus\Dispatchable;
@@ -15,6 +16,7 @@ class PublishTaskCreatedEvent implements ShouldQueue
     use Dispatchable, InteractsWithQueue, Queueable, SerializesModels;
 
     public Task $task;
+    public string $traceparent;
 
     /**
      * Create a new job instance.
@@ -22,6 +24,7 @@ class PublishTaskCreatedEvent implements ShouldQueue
     public function __construct(Task $task)
     {
         $this->task = $task;
+        $this->traceparent = TraceContext::fromRequest();
     }
 
     /**
@@ -38,9 +41,11 @@ public function handle(RabbitMQService $rabbitmq): void
             'priority' => $task->priority,
             'project_id' => $task->project_id,
             'skills' => $task->required_skills ?? [],
+            'estimated_hours' => $task->estimated_hours,
+            'due_date' => $task->due_date?->toDateString(),
             'created_at' => $task->created_at->toIso8601String(),
         ];
 
-        $rabbitmq->publish('tasks', 'task.created', $eventData);
+        $rabbitmq->publish('tasks', 'task.created', $eventData, ['traceparent' => $this->traceparent]);
     }
 }
@@ -0,0 +1,56 @@
+<?php
+
+namespace App\Jobs;
+
+use App\Models\Task;
+use App\Services\RabbitMQService;
+use App\Services\TraceContext;
+use Illuminate\Bus\Queueable;
+use Illuminate\Contracts\Queue\ShouldQueue;
+use Illuminate\Foundation\Bus\Dispatchable;
+use Illuminate\Queue\InteractsWithQueue;
+use Illuminate\Queue\SerializesModels;
+
+class PublishTaskLifecycleEvent implements ShouldQueue
+{
+    use Dispatchable, InteractsWithQueue, Queueable, SerializesModels;
+
+    public Task $task;
+    public string $routingKey;
+    public ?int $previousAssigneeId;
+    public string $traceparent;
+
+    /**
+     * Create a new job instance.
+     */
+    public function __construct(Task $task, string $routingKey, ?int $previousAssigneeId = null)
+    {
+        $this->task = $task;
+        $this->routingKey = $routingKey;
+        $this->previousAssigneeId = $previousAssigneeId;
+        $this->traceparent = TraceContext::fromRequest();
+    }
+
+    /**
+     * Execute the job.
+     */
+    public function handle(RabbitMQService $rabbitmq): void
+    {
+        $task = $this->task->fresh();
+
+        if (!$task) {
+            return;
+        }
+
+        $eventData = [
+            'task_id' => $task->id,
+            'assignee_id' => $task->assigned_user_id ?? 0,
+            'previous_assignee_id' => $this->previousAssigneeId ?? 0,
+            'status' => $task->status,
+            'estimated_hours' => $task->estimated_hours ?? 0,
+            'occurred_at' => $task->updated_at->toIso8601String(),
+        ];
+
+        $rabbitmq->publish('tasks', $this->routingKey, $eventData, ['traceparent' => $this->traceparent]);
+    }
+}
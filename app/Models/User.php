@@ -27,6 +27,7 @@ class User extends Authenticatable
         'skills',
         'workload',
         'max_workload',
+        'working_days',
     ];
 
     /**
@@ -48,6 +49,7 @@ class User extends Authenticatable
         'email_verified_at' => 'datetime',
         'password' => 'hashed',
         'skills' => 'array',
+        'working_days' => 'array',
     ];
 
     public function tasks()
@@ -64,4 +66,9 @@ public function taskLogs()
     {
         return $this->hasMany(TaskLog::class);
     }
+
+    public function absences()
+    {
+        return $this->hasMany(UserAbsence::class);
+    }
 }
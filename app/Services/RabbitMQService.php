@@ -4,6 +4,7 @@
 
 use PhpAmqpLib\Connection\AMQPStreamConnection;
 use PhpAmqpLib\Message\AMQPMessage;
+use PhpAmqpLib\Wire\AMQPTable;
 use Illuminate\Support\Facades\Log;
 
 class RabbitMQService
@@ -46,22 +47,27 @@ private function connect(): void
      * @param string $exchange
      * @param string $routingKey
      * @param array $data
+     * @param array $headers AMQP application headers, e.g. the W3C traceparent
      * @return void
      */
-    public function publish(string $exchange, string $routingKey, array $data): void
+    public function publish(string $exchange, string $routingKey, array $data, array $headers = []): void
     {
         $this->connect();
 
-        $message = new AMQPMessage(
-            json_encode($data),
-            ['delivery_mode' => AMQPMessage::DELIVERY_MODE_PERSISTENT]
-        );
+        $properties = ['delivery_mode' => AMQPMessage::DELIVERY_MODE_PERSISTENT];
+
+        if ($headers !== []) {
+            $properties['application_headers'] = new AMQPTable($headers);
+        }
+
+        $message = new AMQPMessage(json_encode($data), $properties);
 
         $this->channel->basic_publish($message, $exchange, $routingKey);
 
         Log::info('Published message to RabbitMQ', [
             'exchange' => $exchange,
             'routing_key' => $routingKey,
+            'headers' => $headers,
             'data' => $data,
         ]);
     }
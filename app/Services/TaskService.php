@@ -4,6 +4,7 @@
 
 use App\Contracts\Repositories\TaskRepositoryInterface;
 use App\Events\TaskCreated;
+use App\Jobs\PublishTaskLifecycleEvent;
 use App\Models\Task;
 use Illuminate\Database\Eloquent\Collection;
 use Illuminate\Support\Facades\Log;
@@ -44,10 +45,14 @@ public function updateTask(Task $task, array $data): Task
     {
         $oldProjectId = $task->project_id;
         $oldAssignedUserId = $task->assigned_user_id;
+        $oldStatus = $task->status;
+        $oldEstimatedHours = $task->estimated_hours;
 
         $task = $this->taskRepository->update($task, $data);
         $this->cacheService->invalidateTaskWithOldRelations($task, $oldProjectId, $oldAssignedUserId);
 
+        $this->publishLifecycleEvent($task, $oldStatus, $oldAssignedUserId, $oldEstimatedHours);
+
         return $task;
     }
 
@@ -105,4 +110,28 @@ public function assignTask(int|Task $taskOrId, int $userId, array $metadata = []
 
         return $task;
     }
+
+    /**
+     * Notify the task optimizer about changes that release or move assigned capacity
+     */
+    private function publishLifecycleEvent(
+        Task $task,
+        ?string $oldStatus,
+        ?int $oldAssignedUserId,
+        ?int $oldEstimatedHours
+    ): void {
+        $statusChanged = $task->status !== $oldStatus;
+
+        $routingKey = match (true) {
+            $statusChanged && $task->status === 'completed' => 'task.completed',
+            $statusChanged && $task->status === 'cancelled' => 'task.cancelled',
+            $task->assigned_user_id !== $oldAssignedUserId => 'task.reassigned',
+            $statusChanged || $task->estimated_hours !== $oldEstimatedHours => 'task.updated',
+            default => null,
+        };
+
+        if ($routingKey !== null) {
+            PublishTaskLifecycleEvent::dispatch($task, $routingKey, $oldAssignedUserId);
+        }
+    }
 }
@@ -0,0 +1,50 @@
+<?php
+
+namespace App\Services;
+
+class TraceContext
+{
+    private const TRACEPARENT_PATTERN = '/^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$/';
+
+    /**
+     * Build a W3C traceparent header for an outgoing message.
+     *
+     * Continues the trace of a valid incoming traceparent with a new parent id,
+     * otherwise starts a new sampled trace.
+     *
+     * @param string|null $incoming
+     * @return string
+     */
+    public static function traceparent(?string $incoming = null): string
+    {
+        if ($incoming !== null
+            && preg_match(self::TRACEPARENT_PATTERN, strtolower(trim($incoming)), $matches)
+            && !self::isZero($matches[1])
+            && !self::isZero($matches[2])
+        ) {
+            return sprintf('00-%s-%s-%s', $matches[1], self::randomId(8), $matches[3]);
+        }
+
+        return sprintf('00-%s-%s-01', self::randomId(16), self::randomId(8));
+    }
+
+    /**
+     * Build a traceparent continuing the trace of the current HTTP request, if any.
+     *
+     * @return string
+     */
+    public static function fromRequest(): string
+    {
+        return self::traceparent(request()->header('traceparent'));
+    }
+
+    private static function randomId(int $bytes): string
+    {
+        return bin2hex(random_bytes($bytes));
+    }
+
+    private static function isZero(string $id): bool
+    {
+        return trim($id, '0') === '';
+    }
+}
@@ -0,0 +1,28 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::create('user_absences', function (Blueprint $table) {
+            $table->id();
+            $table->foreignId('user_id')->constrained()->onDelete('cascade');
+            $table->enum('type', ['vacation', 'sick_leave', 'holiday', 'other'])->default('vacation');
+            $table->date('starts_on');
+            $table->date('ends_on');
+            $table->text('note')->nullable();
+            $table->timestamps();
+
+            $table->index(['user_id', 'starts_on', 'ends_on']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('user_absences');
+    }
+};
@@ -0,0 +1,27 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        // Written by the task optimizer: capacity each open task holds on its assignee
+        Schema::create('task_load_ledger', function (Blueprint $table) {
+            $table->foreignId('task_id')->primary()->constrained()->onDelete('cascade');
+            $table->foreignId('user_id')->constrained()->onDelete('cascade');
+            $table->integer('estimated_hours')->nullable();
+            $table->timestamp('released_at')->nullable();
+            $table->timestamps();
+
+            $table->index(['user_id', 'released_at']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('task_load_ledger');
+    }
+};
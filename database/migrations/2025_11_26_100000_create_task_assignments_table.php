@@ -0,0 +1,27 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        // Written by the task optimizer: the assignment decided for each task
+        Schema::create('task_assignments', function (Blueprint $table) {
+            $table->foreignId('task_id')->primary()->constrained()->onDelete('cascade');
+            $table->foreignId('user_id')->constrained()->onDelete('cascade');
+            $table->double('score');
+            $table->text('reason');
+            $table->json('alternatives')->nullable();
+            $table->timestamp('assigned_at');
+            $table->timestamps();
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('task_assignments');
+    }
+};
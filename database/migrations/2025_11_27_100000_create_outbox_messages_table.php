@@ -0,0 +1,30 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        // Transactional outbox of the task optimizer, relayed to RabbitMQ
+        Schema::create('outbox_messages', function (Blueprint $table) {
+            $table->id();
+            $table->string('routing_key');
+            $table->json('payload');
+            $table->integer('attempts')->default(0);
+            $table->text('last_error')->nullable();
+            $table->timestamp('locked_until')->nullable();
+            $table->timestamp('sent_at')->nullable();
+            $table->timestamps();
+
+            $table->index(['sent_at', 'id']);
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::dropIfExists('outbox_messages');
+    }
+};
@@ -0,0 +1,23 @@
+<?php
+
+use Illuminate\Database\Migrations\Migration;
+use Illuminate\Database\Schema\Blueprint;
+use Illuminate\Support\Facades\Schema;
+
+return new class extends Migration
+{
+    public function up(): void
+    {
+        Schema::table('outbox_messages', function (Blueprint $table) {
+            // W3C trace context of the operation that queued the message, continued when it is relayed
+            $table->json('trace_context')->nullable()->after('payload');
+        });
+    }
+
+    public function down(): void
+    {
+        Schema::table('outbox_messages', function (Blueprint $table) {
+            $table->dropColumn('trace_context');
+        });
+    }
+};
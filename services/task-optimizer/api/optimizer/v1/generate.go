@@ -0,0 +1,4 @@
+// Package optimizerv1 holds the gRPC API of the task optimizer, generated from optimizer.proto
+package optimizerv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative optimizer/v1/optimizer.proto
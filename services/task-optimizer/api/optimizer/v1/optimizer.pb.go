@@ -0,0 +1,1226 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: optimizer/v1/optimizer.proto
+
+package optimizerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Task is a task to score, shaped like the task.created event.
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TaskId      int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Priority from 1 to 5.
+	Priority       int32               `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	ProjectId      int64               `protobuf:"varint,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Skills         []*SkillRequirement `protobuf:"bytes,6,rep,name=skills,proto3" json:"skills,omitempty"`
+	EstimatedHours int32               `protobuf:"varint,7,opt,name=estimated_hours,json=estimatedHours,proto3" json:"estimated_hours,omitempty"`
+	// Due date as YYYY-MM-DD, empty when the task has none.
+	DueDate       string `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Task) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetSkills() []*SkillRequirement {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *Task) GetEstimatedHours() int32 {
+	if x != nil {
+		return x.EstimatedHours
+	}
+	return 0
+}
+
+func (x *Task) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+type SkillRequirement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinLevel      int32                  `protobuf:"varint,2,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"`
+	Weight        float64                `protobuf:"fixed64,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkillRequirement) Reset() {
+	*x = SkillRequirement{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkillRequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillRequirement) ProtoMessage() {}
+
+func (x *SkillRequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillRequirement.ProtoReflect.Descriptor instead.
+func (*SkillRequirement) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SkillRequirement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SkillRequirement) GetMinLevel() int32 {
+	if x != nil {
+		return x.MinLevel
+	}
+	return 0
+}
+
+func (x *SkillRequirement) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// Candidate is a user scored for a task.
+type Candidate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	SkillScore    float64                `protobuf:"fixed64,4,opt,name=skill_score,json=skillScore,proto3" json:"skill_score,omitempty"`
+	LoadScore     float64                `protobuf:"fixed64,5,opt,name=load_score,json=loadScore,proto3" json:"load_score,omitempty"`
+	PriorityBonus float64                `protobuf:"fixed64,6,opt,name=priority_bonus,json=priorityBonus,proto3" json:"priority_bonus,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Factors       []*Factor              `protobuf:"bytes,8,rep,name=factors,proto3" json:"factors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candidate) Reset() {
+	*x = Candidate{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candidate) ProtoMessage() {}
+
+func (x *Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candidate.ProtoReflect.Descriptor instead.
+func (*Candidate) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Candidate) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Candidate) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *Candidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Candidate) GetSkillScore() float64 {
+	if x != nil {
+		return x.SkillScore
+	}
+	return 0
+}
+
+func (x *Candidate) GetLoadScore() float64 {
+	if x != nil {
+		return x.LoadScore
+	}
+	return 0
+}
+
+func (x *Candidate) GetPriorityBonus() float64 {
+	if x != nil {
+		return x.PriorityBonus
+	}
+	return 0
+}
+
+func (x *Candidate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Candidate) GetFactors() []*Factor {
+	if x != nil {
+		return x.Factors
+	}
+	return nil
+}
+
+// Factor is the score of one scoring factor.
+type Factor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Explanation   string                 `protobuf:"bytes,4,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Factor) Reset() {
+	*x = Factor{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Factor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Factor) ProtoMessage() {}
+
+func (x *Factor) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Factor.ProtoReflect.Descriptor instead.
+func (*Factor) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Factor) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Factor) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Factor) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Factor) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+// Exclusion tells why a user is not a candidate.
+type Exclusion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Exclusion) Reset() {
+	*x = Exclusion{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Exclusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exclusion) ProtoMessage() {}
+
+func (x *Exclusion) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exclusion.ProtoReflect.Descriptor instead.
+func (*Exclusion) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Exclusion) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Exclusion) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *Exclusion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Assignment is a task assigned to a user.
+type Assignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AssigneeId    int64                  `protobuf:"varint,2,opt,name=assignee_id,json=assigneeId,proto3" json:"assignee_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Alternatives  []*Candidate           `protobuf:"bytes,5,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Assignment) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Assignment) GetAssigneeId() int64 {
+	if x != nil {
+		return x.AssigneeId
+	}
+	return 0
+}
+
+func (x *Assignment) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Assignment) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Assignment) GetAlternatives() []*Candidate {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+func (x *Assignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type RecommendRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Task ID and title may be empty for a task that is still being written.
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Maximum number of candidates, 0 for the default of 5.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendRequest) Reset() {
+	*x = RecommendRequest{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendRequest) ProtoMessage() {}
+
+func (x *RecommendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendRequest.ProtoReflect.Descriptor instead.
+func (*RecommendRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecommendRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *RecommendRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecommendResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Candidates []*Candidate           `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Set when no user is eligible.
+	Exclusions    []*Exclusion `protobuf:"bytes,2,rep,name=exclusions,proto3" json:"exclusions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendResponse) Reset() {
+	*x = RecommendResponse{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendResponse) ProtoMessage() {}
+
+func (x *RecommendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendResponse.ProtoReflect.Descriptor instead.
+func (*RecommendResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecommendResponse) GetCandidates() []*Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *RecommendResponse) GetExclusions() []*Exclusion {
+	if x != nil {
+		return x.Exclusions
+	}
+	return nil
+}
+
+type AssignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignRequest) Reset() {
+	*x = AssignRequest{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignRequest) ProtoMessage() {}
+
+func (x *AssignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignRequest.ProtoReflect.Descriptor instead.
+func (*AssignRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AssignRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type AssignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *Assignment            `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignResponse) Reset() {
+	*x = AssignResponse{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignResponse) ProtoMessage() {}
+
+func (x *AssignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignResponse.ProtoReflect.Descriptor instead.
+func (*AssignResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AssignResponse) GetAssignment() *Assignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+type BatchAssignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAssignRequest) Reset() {
+	*x = BatchAssignRequest{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAssignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAssignRequest) ProtoMessage() {}
+
+func (x *BatchAssignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAssignRequest.ProtoReflect.Descriptor instead.
+func (*BatchAssignRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchAssignRequest) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type BatchAssignResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stored assignments, in request order.
+	Assignments       []*Assignment `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	UnassignedTaskIds []int64       `protobuf:"varint,2,rep,packed,name=unassigned_task_ids,json=unassignedTaskIds,proto3" json:"unassigned_task_ids,omitempty"`
+	// Sum of the scores of the stored assignments.
+	TotalScore    float64        `protobuf:"fixed64,3,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`
+	Failures      []*TaskFailure `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAssignResponse) Reset() {
+	*x = BatchAssignResponse{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAssignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAssignResponse) ProtoMessage() {}
+
+func (x *BatchAssignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAssignResponse.ProtoReflect.Descriptor instead.
+func (*BatchAssignResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchAssignResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+func (x *BatchAssignResponse) GetUnassignedTaskIds() []int64 {
+	if x != nil {
+		return x.UnassignedTaskIds
+	}
+	return nil
+}
+
+func (x *BatchAssignResponse) GetTotalScore() float64 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+func (x *BatchAssignResponse) GetFailures() []*TaskFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// TaskFailure tells why a task of a batch could not be assigned.
+type TaskFailure struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// gRPC status code the error maps to, as in google.rpc.Code.
+	Code          int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFailure) Reset() {
+	*x = TaskFailure{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFailure) ProtoMessage() {}
+
+func (x *TaskFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFailure.ProtoReflect.Descriptor instead.
+func (*TaskFailure) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskFailure) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskFailure) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *TaskFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ExplainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainRequest) Reset() {
+	*x = ExplainRequest{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainRequest) ProtoMessage() {}
+
+func (x *ExplainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainRequest.ProtoReflect.Descriptor instead.
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExplainRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ExplainRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ExplainResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Set when the user is a candidate.
+	Candidate *Candidate `protobuf:"bytes,1,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	// 1-based rank among the candidates, 0 when the user is not a candidate.
+	Rank       int32 `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Candidates int32 `protobuf:"varint,3,opt,name=candidates,proto3" json:"candidates,omitempty"`
+	// Set when the user is not a candidate.
+	Exclusion     *Exclusion `protobuf:"bytes,4,opt,name=exclusion,proto3" json:"exclusion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExplainResponse) Reset() {
+	*x = ExplainResponse{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainResponse) ProtoMessage() {}
+
+func (x *ExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainResponse.ProtoReflect.Descriptor instead.
+func (*ExplainResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExplainResponse) GetCandidate() *Candidate {
+	if x != nil {
+		return x.Candidate
+	}
+	return nil
+}
+
+func (x *ExplainResponse) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetCandidates() int32 {
+	if x != nil {
+		return x.Candidates
+	}
+	return 0
+}
+
+func (x *ExplainResponse) GetExclusion() *Exclusion {
+	if x != nil {
+		return x.Exclusion
+	}
+	return nil
+}
+
+type WatchAssignmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream assignments to these users, all assignments when empty.
+	AssigneeIds   []int64 `protobuf:"varint,1,rep,packed,name=assignee_ids,json=assigneeIds,proto3" json:"assignee_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAssignmentsRequest) Reset() {
+	*x = WatchAssignmentsRequest{}
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAssignmentsRequest) ProtoMessage() {}
+
+func (x *WatchAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_v1_optimizer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_v1_optimizer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchAssignmentsRequest) GetAssigneeIds() []int64 {
+	if x != nil {
+		return x.AssigneeIds
+	}
+	return nil
+}
+
+var File_optimizer_v1_optimizer_proto protoreflect.FileDescriptor
+
+const file_optimizer_v1_optimizer_proto_rawDesc = "" +
+	"\n" +
+	"\x1coptimizer/v1/optimizer.proto\x12\foptimizer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8e\x02\n" +
+	"\x04Task\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x05 \x01(\x03R\tprojectId\x126\n" +
+	"\x06skills\x18\x06 \x03(\v2\x1e.optimizer.v1.SkillRequirementR\x06skills\x12'\n" +
+	"\x0festimated_hours\x18\a \x01(\x05R\x0eestimatedHours\x12\x19\n" +
+	"\bdue_date\x18\b \x01(\tR\adueDate\"[\n" +
+	"\x10SkillRequirement\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmin_level\x18\x02 \x01(\x05R\bminLevel\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x01R\x06weight\"\x86\x02\n" +
+	"\tCandidate\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x1f\n" +
+	"\vskill_score\x18\x04 \x01(\x01R\n" +
+	"skillScore\x12\x1d\n" +
+	"\n" +
+	"load_score\x18\x05 \x01(\x01R\tloadScore\x12%\n" +
+	"\x0epriority_bonus\x18\x06 \x01(\x01R\rpriorityBonus\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12.\n" +
+	"\afactors\x18\b \x03(\v2\x14.optimizer.v1.FactorR\afactors\"l\n" +
+	"\x06Factor\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12 \n" +
+	"\vexplanation\x18\x04 \x01(\tR\vexplanation\"Y\n" +
+	"\tExclusion\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xee\x01\n" +
+	"\n" +
+	"Assignment\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1f\n" +
+	"\vassignee_id\x18\x02 \x01(\x03R\n" +
+	"assigneeId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12;\n" +
+	"\falternatives\x18\x05 \x03(\v2\x17.optimizer.v1.CandidateR\falternatives\x12;\n" +
+	"\vassigned_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\"P\n" +
+	"\x10RecommendRequest\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.optimizer.v1.TaskR\x04task\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x85\x01\n" +
+	"\x11RecommendResponse\x127\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2\x17.optimizer.v1.CandidateR\n" +
+	"candidates\x127\n" +
+	"\n" +
+	"exclusions\x18\x02 \x03(\v2\x17.optimizer.v1.ExclusionR\n" +
+	"exclusions\"7\n" +
+	"\rAssignRequest\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.optimizer.v1.TaskR\x04task\"J\n" +
+	"\x0eAssignResponse\x128\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x18.optimizer.v1.AssignmentR\n" +
+	"assignment\">\n" +
+	"\x12BatchAssignRequest\x12(\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x12.optimizer.v1.TaskR\x05tasks\"\xd9\x01\n" +
+	"\x13BatchAssignResponse\x12:\n" +
+	"\vassignments\x18\x01 \x03(\v2\x18.optimizer.v1.AssignmentR\vassignments\x12.\n" +
+	"\x13unassigned_task_ids\x18\x02 \x03(\x03R\x11unassignedTaskIds\x12\x1f\n" +
+	"\vtotal_score\x18\x03 \x01(\x01R\n" +
+	"totalScore\x125\n" +
+	"\bfailures\x18\x04 \x03(\v2\x19.optimizer.v1.TaskFailureR\bfailures\"T\n" +
+	"\vTaskFailure\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"Q\n" +
+	"\x0eExplainRequest\x12&\n" +
+	"\x04task\x18\x01 \x01(\v2\x12.optimizer.v1.TaskR\x04task\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xb3\x01\n" +
+	"\x0fExplainResponse\x125\n" +
+	"\tcandidate\x18\x01 \x01(\v2\x17.optimizer.v1.CandidateR\tcandidate\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12\x1e\n" +
+	"\n" +
+	"candidates\x18\x03 \x01(\x05R\n" +
+	"candidates\x125\n" +
+	"\texclusion\x18\x04 \x01(\v2\x17.optimizer.v1.ExclusionR\texclusion\"<\n" +
+	"\x17WatchAssignmentsRequest\x12!\n" +
+	"\fassignee_ids\x18\x01 \x03(\x03R\vassigneeIds2\x95\x03\n" +
+	"\rTaskOptimizer\x12L\n" +
+	"\tRecommend\x12\x1e.optimizer.v1.RecommendRequest\x1a\x1f.optimizer.v1.RecommendResponse\x12C\n" +
+	"\x06Assign\x12\x1b.optimizer.v1.AssignRequest\x1a\x1c.optimizer.v1.AssignResponse\x12R\n" +
+	"\vBatchAssign\x12 .optimizer.v1.BatchAssignRequest\x1a!.optimizer.v1.BatchAssignResponse\x12F\n" +
+	"\aExplain\x12\x1c.optimizer.v1.ExplainRequest\x1a\x1d.optimizer.v1.ExplainResponse\x12U\n" +
+	"\x10WatchAssignments\x12%.optimizer.v1.WatchAssignmentsRequest\x1a\x18.optimizer.v1.Assignment0\x01B-Z+task-optimizer/api/optimizer/v1;optimizerv1b\x06proto3"
+
+var (
+	file_optimizer_v1_optimizer_proto_rawDescOnce sync.Once
+	file_optimizer_v1_optimizer_proto_rawDescData []byte
+)
+
+func file_optimizer_v1_optimizer_proto_rawDescGZIP() []byte {
+	file_optimizer_v1_optimizer_proto_rawDescOnce.Do(func() {
+		file_optimizer_v1_optimizer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_optimizer_v1_optimizer_proto_rawDesc), len(file_optimizer_v1_optimizer_proto_rawDesc)))
+	})
+	return file_optimizer_v1_optimizer_proto_rawDescData
+}
+
+var file_optimizer_v1_optimizer_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_optimizer_v1_optimizer_proto_goTypes = []any{
+	(*Task)(nil),                    // 0: optimizer.v1.Task
+	(*SkillRequirement)(nil),        // 1: optimizer.v1.SkillRequirement
+	(*Candidate)(nil),               // 2: optimizer.v1.Candidate
+	(*Factor)(nil),                  // 3: optimizer.v1.Factor
+	(*Exclusion)(nil),               // 4: optimizer.v1.Exclusion
+	(*Assignment)(nil),              // 5: optimizer.v1.Assignment
+	(*RecommendRequest)(nil),        // 6: optimizer.v1.RecommendRequest
+	(*RecommendResponse)(nil),       // 7: optimizer.v1.RecommendResponse
+	(*AssignRequest)(nil),           // 8: optimizer.v1.AssignRequest
+	(*AssignResponse)(nil),          // 9: optimizer.v1.AssignResponse
+	(*BatchAssignRequest)(nil),      // 10: optimizer.v1.BatchAssignRequest
+	(*BatchAssignResponse)(nil),     // 11: optimizer.v1.BatchAssignResponse
+	(*TaskFailure)(nil),             // 12: optimizer.v1.TaskFailure
+	(*ExplainRequest)(nil),          // 13: optimizer.v1.ExplainRequest
+	(*ExplainResponse)(nil),         // 14: optimizer.v1.ExplainResponse
+	(*WatchAssignmentsRequest)(nil), // 15: optimizer.v1.WatchAssignmentsRequest
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
+}
+var file_optimizer_v1_optimizer_proto_depIdxs = []int32{
+	1,  // 0: optimizer.v1.Task.skills:type_name -> optimizer.v1.SkillRequirement
+	3,  // 1: optimizer.v1.Candidate.factors:type_name -> optimizer.v1.Factor
+	2,  // 2: optimizer.v1.Assignment.alternatives:type_name -> optimizer.v1.Candidate
+	16, // 3: optimizer.v1.Assignment.assigned_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: optimizer.v1.RecommendRequest.task:type_name -> optimizer.v1.Task
+	2,  // 5: optimizer.v1.RecommendResponse.candidates:type_name -> optimizer.v1.Candidate
+	4,  // 6: optimizer.v1.RecommendResponse.exclusions:type_name -> optimizer.v1.Exclusion
+	0,  // 7: optimizer.v1.AssignRequest.task:type_name -> optimizer.v1.Task
+	5,  // 8: optimizer.v1.AssignResponse.assignment:type_name -> optimizer.v1.Assignment
+	0,  // 9: optimizer.v1.BatchAssignRequest.tasks:type_name -> optimizer.v1.Task
+	5,  // 10: optimizer.v1.BatchAssignResponse.assignments:type_name -> optimizer.v1.Assignment
+	12, // 11: optimizer.v1.BatchAssignResponse.failures:type_name -> optimizer.v1.TaskFailure
+	0,  // 12: optimizer.v1.ExplainRequest.task:type_name -> optimizer.v1.Task
+	2,  // 13: optimizer.v1.ExplainResponse.candidate:type_name -> optimizer.v1.Candidate
+	4,  // 14: optimizer.v1.ExplainResponse.exclusion:type_name -> optimizer.v1.Exclusion
+	6,  // 15: optimizer.v1.TaskOptimizer.Recommend:input_type -> optimizer.v1.RecommendRequest
+	8,  // 16: optimizer.v1.TaskOptimizer.Assign:input_type -> optimizer.v1.AssignRequest
+	10, // 17: optimizer.v1.TaskOptimizer.BatchAssign:input_type -> optimizer.v1.BatchAssignRequest
+	13, // 18: optimizer.v1.TaskOptimizer.Explain:input_type -> optimizer.v1.ExplainRequest
+	15, // 19: optimizer.v1.TaskOptimizer.WatchAssignments:input_type -> optimizer.v1.WatchAssignmentsRequest
+	7,  // 20: optimizer.v1.TaskOptimizer.Recommend:output_type -> optimizer.v1.RecommendResponse
+	9,  // 21: optimizer.v1.TaskOptimizer.Assign:output_type -> optimizer.v1.AssignResponse
+	11, // 22: optimizer.v1.TaskOptimizer.BatchAssign:output_type -> optimizer.v1.BatchAssignResponse
+	14, // 23: optimizer.v1.TaskOptimizer.Explain:output_type -> optimizer.v1.ExplainResponse
+	5,  // 24: optimizer.v1.TaskOptimizer.WatchAssignments:output_type -> optimizer.v1.Assignment
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_optimizer_v1_optimizer_proto_init() }
+func file_optimizer_v1_optimizer_proto_init() {
+	if File_optimizer_v1_optimizer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_optimizer_v1_optimizer_proto_rawDesc), len(file_optimizer_v1_optimizer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_optimizer_v1_optimizer_proto_goTypes,
+		DependencyIndexes: file_optimizer_v1_optimizer_proto_depIdxs,
+		MessageInfos:      file_optimizer_v1_optimizer_proto_msgTypes,
+	}.Build()
+	File_optimizer_v1_optimizer_proto = out.File
+	file_optimizer_v1_optimizer_proto_goTypes = nil
+	file_optimizer_v1_optimizer_proto_depIdxs = nil
+}
@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: optimizer/v1/optimizer.proto
+
+package optimizerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TaskOptimizer_Recommend_FullMethodName        = "/optimizer.v1.TaskOptimizer/Recommend"
+	TaskOptimizer_Assign_FullMethodName           = "/optimizer.v1.TaskOptimizer/Assign"
+	TaskOptimizer_BatchAssign_FullMethodName      = "/optimizer.v1.TaskOptimizer/BatchAssign"
+	TaskOptimizer_Explain_FullMethodName          = "/optimizer.v1.TaskOptimizer/Explain"
+	TaskOptimizer_WatchAssignments_FullMethodName = "/optimizer.v1.TaskOptimizer/WatchAssignments"
+)
+
+// TaskOptimizerClient is the client API for TaskOptimizer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TaskOptimizer ranks users for tasks and assigns them.
+type TaskOptimizerClient interface {
+	// Recommend ranks candidates for a task without assigning it.
+	Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*RecommendResponse, error)
+	// Assign assigns a task and queues its task.assigned event.
+	// Assigning an already assigned task returns the stored assignment.
+	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
+	// BatchAssign assigns several tasks jointly so they are spread over the team.
+	// Already assigned tasks keep their stored assignment. Tasks whose assignment
+	// could not be stored are reported per task instead of failing the call.
+	BatchAssign(ctx context.Context, in *BatchAssignRequest, opts ...grpc.CallOption) (*BatchAssignResponse, error)
+	// Explain reports how one user scores and ranks for a task, or why the user is not a candidate.
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	// WatchAssignments streams the assignments committed by the serving instance.
+	WatchAssignments(ctx context.Context, in *WatchAssignmentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Assignment], error)
+}
+
+type taskOptimizerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaskOptimizerClient(cc grpc.ClientConnInterface) TaskOptimizerClient {
+	return &taskOptimizerClient{cc}
+}
+
+func (c *taskOptimizerClient) Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*RecommendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendResponse)
+	err := c.cc.Invoke(ctx, TaskOptimizer_Recommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskOptimizerClient) Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignResponse)
+	err := c.cc.Invoke(ctx, TaskOptimizer_Assign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskOptimizerClient) BatchAssign(ctx context.Context, in *BatchAssignRequest, opts ...grpc.CallOption) (*BatchAssignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchAssignResponse)
+	err := c.cc.Invoke(ctx, TaskOptimizer_BatchAssign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskOptimizerClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, TaskOptimizer_Explain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskOptimizerClient) WatchAssignments(ctx context.Context, in *WatchAssignmentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Assignment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskOptimizer_ServiceDesc.Streams[0], TaskOptimizer_WatchAssignments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAssignmentsRequest, Assignment]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskOptimizer_WatchAssignmentsClient = grpc.ServerStreamingClient[Assignment]
+
+// TaskOptimizerServer is the server API for TaskOptimizer service.
+// All implementations must embed UnimplementedTaskOptimizerServer
+// for forward compatibility.
+//
+// TaskOptimizer ranks users for tasks and assigns them.
+type TaskOptimizerServer interface {
+	// Recommend ranks candidates for a task without assigning it.
+	Recommend(context.Context, *RecommendRequest) (*RecommendResponse, error)
+	// Assign assigns a task and queues its task.assigned event.
+	// Assigning an already assigned task returns the stored assignment.
+	Assign(context.Context, *AssignRequest) (*AssignResponse, error)
+	// BatchAssign assigns several tasks jointly so they are spread over the team.
+	// Already assigned tasks keep their stored assignment. Tasks whose assignment
+	// could not be stored are reported per task instead of failing the call.
+	BatchAssign(context.Context, *BatchAssignRequest) (*BatchAssignResponse, error)
+	// Explain reports how one user scores and ranks for a task, or why the user is not a candidate.
+	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
+	// WatchAssignments streams the assignments committed by the serving instance.
+	WatchAssignments(*WatchAssignmentsRequest, grpc.ServerStreamingServer[Assignment]) error
+	mustEmbedUnimplementedTaskOptimizerServer()
+}
+
+// UnimplementedTaskOptimizerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTaskOptimizerServer struct{}
+
+func (UnimplementedTaskOptimizerServer) Recommend(context.Context, *RecommendRequest) (*RecommendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
+}
+func (UnimplementedTaskOptimizerServer) Assign(context.Context, *AssignRequest) (*AssignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Assign not implemented")
+}
+func (UnimplementedTaskOptimizerServer) BatchAssign(context.Context, *BatchAssignRequest) (*BatchAssignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchAssign not implemented")
+}
+func (UnimplementedTaskOptimizerServer) Explain(context.Context, *ExplainRequest) (*ExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (UnimplementedTaskOptimizerServer) WatchAssignments(*WatchAssignmentsRequest, grpc.ServerStreamingServer[Assignment]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAssignments not implemented")
+}
+func (UnimplementedTaskOptimizerServer) mustEmbedUnimplementedTaskOptimizerServer() {}
+func (UnimplementedTaskOptimizerServer) testEmbeddedByValue()                       {}
+
+// UnsafeTaskOptimizerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TaskOptimizerServer will
+// result in compilation errors.
+type UnsafeTaskOptimizerServer interface {
+	mustEmbedUnimplementedTaskOptimizerServer()
+}
+
+func RegisterTaskOptimizerServer(s grpc.ServiceRegistrar, srv TaskOptimizerServer) {
+	// If the following call pancis, it indicates UnimplementedTaskOptimizerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TaskOptimizer_ServiceDesc, srv)
+}
+
+func _TaskOptimizer_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskOptimizerServer).Recommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskOptimizer_Recommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskOptimizerServer).Recommend(ctx, req.(*RecommendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskOptimizer_Assign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskOptimizerServer).Assign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskOptimizer_Assign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskOptimizerServer).Assign(ctx, req.(*AssignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskOptimizer_BatchAssign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAssignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskOptimizerServer).BatchAssign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskOptimizer_BatchAssign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskOptimizerServer).BatchAssign(ctx, req.(*BatchAssignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskOptimizer_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskOptimizerServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskOptimizer_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskOptimizerServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskOptimizer_WatchAssignments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAssignmentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskOptimizerServer).WatchAssignments(m, &grpc.GenericServerStream[WatchAssignmentsRequest, Assignment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskOptimizer_WatchAssignmentsServer = grpc.ServerStreamingServer[Assignment]
+
+// TaskOptimizer_ServiceDesc is the grpc.ServiceDesc for TaskOptimizer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaskOptimizer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "optimizer.v1.TaskOptimizer",
+	HandlerType: (*TaskOptimizerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Recommend",
+			Handler:    _TaskOptimizer_Recommend_Handler,
+		},
+		{
+			MethodName: "Assign",
+			Handler:    _TaskOptimizer_Assign_Handler,
+		},
+		{
+			MethodName: "BatchAssign",
+			Handler:    _TaskOptimizer_BatchAssign_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _TaskOptimizer_Explain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAssignments",
+			Handler:       _TaskOptimizer_WatchAssignments_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "optimizer/v1/optimizer.proto",
+}
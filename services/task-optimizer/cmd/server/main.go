@@ -13,7 +13,12 @@ import (
 	"task-optimizer/internal/infrastructure/messaging/rabbitmq"
 	"task-optimizer/internal/infrastructure/repository/postgres"
 	"task-optimizer/internal/interfaces/consumer"
+	grpcapi "task-optimizer/internal/interfaces/grpc"
+	httpapi "task-optimizer/internal/interfaces/http"
 	"task-optimizer/pkg/logger"
+	"task-optimizer/pkg/metrics"
+	"task-optimizer/pkg/tracing"
+	"time"
 
 	_ "github.com/lib/pq"
 	"go.uber.org/zap"
@@ -38,50 +43,218 @@ func main() {
 		zap.Int("worker_count", cfg.Service.WorkerCount),
 	)
 
+	shutdownTracing, err := tracing.Setup(
+		context.Background(),
+		cfg.Tracing.Exporter,
+		"task-optimizer",
+		cfg.Tracing.SampleRatio,
+	)
+	if err != nil {
+		log.Fatal("Failed to set up tracing", zap.Error(err))
+	}
+	log.Info("Tracing configured",
+		zap.String("exporter", cfg.Tracing.Exporter),
+		zap.Float64("sample_ratio", cfg.Tracing.SampleRatio),
+	)
+
 	db, err := connectDatabase(cfg.Database, log)
 	if err != nil {
 		log.Fatal("Failed to connect to database", zap.Error(err))
 	}
-	defer func() { _ = db.Close() }()
 
 	rabbitConn, err := rabbitmq.NewConnection(cfg.RabbitMQ.URL, log)
 	if err != nil {
 		log.Fatal("Failed to connect to RabbitMQ", zap.Error(err))
 	}
-	defer func() { _ = rabbitConn.Close() }()
 
-	if err := setupRabbitMQ(rabbitConn, cfg.RabbitMQ, log); err != nil {
+	retryPolicy := rabbitmq.RetryPolicy{
+		MaxAttempts: cfg.RabbitMQ.RetryMaxAttempts,
+		BaseDelay:   cfg.RabbitMQ.RetryBaseDelay,
+	}
+
+	if err := setupRabbitMQ(rabbitConn, cfg.RabbitMQ, retryPolicy, log); err != nil {
 		log.Fatal("Failed to setup RabbitMQ", zap.Error(err))
 	}
 
-	userRepo := postgres.NewUserRepository(db)
+	capacityModel, err := domain.ParseCapacityModel(cfg.Capacity.Model)
+	if err != nil {
+		log.Fatal("Invalid capacity configuration", zap.Error(err))
+	}
+	log.Info("Capacity model configured",
+		zap.String("model", string(capacityModel)),
+		zap.Int("tasks_per_user", cfg.Capacity.TasksPerUser),
+		zap.Int("weekly_hours", cfg.Capacity.WeeklyHours),
+	)
+
+	capacity := domain.CapacityPolicy{
+		Model:            capacityModel,
+		TasksPerUser:     cfg.Capacity.TasksPerUser,
+		WeeklyHours:      cfg.Capacity.WeeklyHours,
+		DefaultTaskHours: cfg.Capacity.DefaultTaskHours,
+	}
+
+	userRepo := postgres.NewUserRepository(db, capacity)
 	publisher := rabbitmq.NewPublisher(rabbitConn, cfg.RabbitMQ.Exchange, log)
-	optimizerService := domain.NewOptimizerService(userRepo)
+
+	normalizer := loadSkillNormalizer(postgres.NewSkillRepository(db), cfg.Skills, log)
+
+	performanceRepo := postgres.NewPerformanceRepository(db, cfg.Scoring.HistoryEstimateTolerance)
+	activityRepo := postgres.NewProjectActivityRepository(db)
+
+	scorer, err := buildScorer(cfg.Scoring, capacity, normalizer, performanceRepo, activityRepo, log)
+	if err != nil {
+		log.Fatal("Failed to build scorer", zap.Error(err))
+	}
+	constraints, err := buildConstraints(cfg.Eligibility, capacity, normalizer, log)
+	if err != nil {
+		log.Fatal("Failed to build constraints", zap.Error(err))
+	}
+
+	availabilityMode, err := domain.ParseAvailabilityMode(cfg.Availability.Mode)
+	if err != nil {
+		log.Fatal("Invalid availability configuration", zap.Error(err))
+	}
+	log.Info("Availability mode configured",
+		zap.String("mode", string(availabilityMode)),
+		zap.Float64("min_share", cfg.Availability.MinShare),
+	)
+
+	availability := domain.NewAvailabilityPolicy(
+		postgres.NewAvailabilityRepository(db),
+		availabilityMode,
+		capacity,
+		cfg.Availability.MinShare,
+	)
+
+	optimizerService := domain.NewOptimizerService(
+		userRepo,
+		domain.WithScorer(scorer),
+		domain.WithCapacity(capacity),
+		domain.WithConstraints(constraints...),
+		domain.WithAvailability(availability),
+	)
+
+	outboxRepo := postgres.NewOutboxRepository(db)
+	recorder := metrics.NewPrometheus()
+	recorder.RegisterBookedTasks(userRepo)
+	assignmentFeed := application.NewAssignmentFeed(log)
 
 	assignTaskUC := application.NewAssignTaskUseCase(
 		optimizerService,
-		userRepo,
-		publisher,
+		postgres.NewAssignmentRepository(db),
+		outboxRepo,
+		cfg.Service.Alternatives,
+		assignmentFeed,
+		recorder,
 		log,
 	)
 
+	trackLoadUC := application.NewTrackLoadUseCase(userRepo, log)
+
 	taskHandler := consumer.NewTaskEventHandler(assignTaskUC, log)
-	taskConsumer := rabbitmq.NewConsumer(
-		rabbitConn,
-		taskHandler.HandleTaskCreated,
+	lifecycleHandler := consumer.NewTaskLifecycleHandler(trackLoadUC, log)
+
+	taskConsumer := rabbitmq.NewConsumer(rabbitConn, retryPolicy, cfg.Service.WorkerCount, recorder, log)
+	taskConsumer.Handle(domain.EventTaskCreated, rabbitmq.JSONHandler(taskHandler.HandleTaskCreated))
+	taskConsumer.Handle(domain.EventTaskCompleted, rabbitmq.JSONHandler(lifecycleHandler.HandleTaskCompleted))
+	taskConsumer.Handle(domain.EventTaskCancelled, rabbitmq.JSONHandler(lifecycleHandler.HandleTaskCancelled))
+	taskConsumer.Handle(domain.EventTaskReassigned, rabbitmq.JSONHandler(lifecycleHandler.HandleTaskReassigned))
+	taskConsumer.Handle(domain.EventTaskUpdated, rabbitmq.JSONHandler(lifecycleHandler.HandleTaskUpdated))
+
+	// Handlers keep running on shutdown until drained, workCtx only aborts them past the deadline
+	workCtx, abortWork := context.WithCancel(context.Background())
+	defer abortWork()
+
+	outboxRelay := application.NewOutboxRelay(
+		outboxRepo,
+		publisher,
+		cfg.Outbox.PollInterval,
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.PublishTimeout,
+		cfg.Outbox.MaxRetryDelay,
 		log,
 	)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		outboxRelay.Run(relayCtx)
+		close(relayDone)
+	}()
+
+	for _, queue := range []string{cfg.RabbitMQ.QueueTaskCreated, cfg.RabbitMQ.QueueTaskLifecycle} {
+		if err := taskConsumer.StartConsuming(workCtx, queue); err != nil {
+			log.Fatal("Failed to start consuming", zap.String("queue", queue), zap.Error(err))
+		}
+	}
+
+	recommendUC := application.NewRecommendAssigneesUseCase(optimizerService, log)
+	recommendationHandler := httpapi.NewRecommendationHandler(recommendUC, log)
+
+	healthHandler := httpapi.NewHealthHandler(map[string]httpapi.HealthCheck{
+		"postgres": db.PingContext,
+		"rabbitmq": rabbitConn.Check,
+		"consumer": taskConsumer.Check,
+	}, log)
+
+	httpServer := httpapi.NewServer(cfg.HTTP.Addr, recommendationHandler, healthHandler, recorder.Handler(), log)
+	if err := httpServer.Start(); err != nil {
+		log.Fatal("Failed to start HTTP server", zap.Error(err))
+	}
+
+	optimizerHandler := grpcapi.NewOptimizerHandler(assignTaskUC, recommendUC, assignmentFeed, log)
 
-	if err := taskConsumer.StartConsuming(ctx, cfg.RabbitMQ.QueueTaskCreated); err != nil {
-		log.Fatal("Failed to start consuming", zap.Error(err))
+	grpcServer := grpcapi.NewServer(cfg.GRPC.Addr, optimizerHandler, log)
+	if err := grpcServer.Start(); err != nil {
+		log.Fatal("Failed to start gRPC server", zap.Error(err))
 	}
 
 	log.Info("Task Optimizer Service is running. Press Ctrl+C to exit.")
 
-	waitForShutdown(cancel, log)
+	waitForShutdown(log)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Service.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// Stop taking deliveries first, so messages in flight finish while the APIs drain
+	// and nothing new arrives while they do
+	taskConsumer.Stop()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("HTTP requests aborted on shutdown", zap.Error(err))
+	}
+
+	// End assignment streams so they do not hold up the graceful stop
+	assignmentFeed.Close()
+	if err := grpcServer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("gRPC calls aborted on shutdown", zap.Error(err))
+	}
+
+	// Wait for the deliveries still in flight
+	if err := taskConsumer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("Shutdown deadline reached, aborting messages in flight", zap.Error(err))
+	}
+	abortWork()
+
+	// Publish the events queued by the last handled messages
+	stopRelay()
+	select {
+	case <-relayDone:
+		if err := outboxRelay.Flush(shutdownCtx); err != nil {
+			log.Warn("Outbox not flushed, pending events are relayed after restart", zap.Error(err))
+		}
+	case <-shutdownCtx.Done():
+		log.Warn("Outbox relay did not stop before shutdown deadline, pending events are relayed after restart")
+	}
+
+	if err := db.Close(); err != nil {
+		log.Error("Failed to close database", zap.Error(err))
+	}
+	_ = rabbitConn.Close()
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Warn("Failed to flush spans", zap.Error(err))
+	}
 
 	log.Info("Task Optimizer Service stopped")
 }
@@ -111,33 +284,143 @@ func connectDatabase(cfg config.DatabaseConfig, log *zap.Logger) (*sql.DB, error
 	return db, nil
 }
 
-// setupRabbitMQ declares exchanges and queues
-func setupRabbitMQ(conn *rabbitmq.Connection, cfg config.RabbitMQConfig, log *zap.Logger) error {
+// loadSkillNormalizer builds the skill normalizer from the skills table and configured aliases.
+// When the catalog cannot be read, only the aliases are used.
+func loadSkillNormalizer(repo domain.SkillRepository, cfg config.SkillsConfig, log *zap.Logger) *domain.SkillNormalizer {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	catalog, err := repo.GetSkills(ctx)
+	if err != nil {
+		log.Warn("Failed to load skills catalog, using aliases only", zap.Error(err))
+		catalog = nil
+	}
+
+	log.Info("Skill normalizer loaded",
+		zap.Int("catalog_skills", len(catalog)),
+		zap.Int("aliases", len(cfg.Aliases)),
+		zap.Float64("related_credit", cfg.RelatedCredit),
+	)
+
+	return domain.NewSkillNormalizer(catalog, cfg.Aliases, cfg.RelatedCredit)
+}
+
+// buildScorer creates the composite scorer from configured factor weights
+func buildScorer(
+	cfg config.ScoringConfig,
+	capacity domain.CapacityPolicy,
+	normalizer *domain.SkillNormalizer,
+	performance domain.PerformanceRepository,
+	activity domain.ProjectActivityRepository,
+	log *zap.Logger,
+) (*domain.CompositeScorer, error) {
+	registry := domain.DefaultFactorRegistry()
+	registry[domain.FactorSkill] = domain.SkillScorer{Normalizer: normalizer}
+	registry[domain.FactorLoad] = domain.LoadScorer{Capacity: capacity}
+	registry[domain.FactorDeadline] = domain.NewDeadlineScorer(capacity)
+	registry[domain.FactorHistory] = domain.NewHistoryScorer(
+		performance,
+		normalizer,
+		cfg.HistoryMinSamples,
+		time.Duration(cfg.HistoryLookbackDays)*24*time.Hour,
+	)
+	registry[domain.FactorAffinity] = domain.NewAffinityScorer(
+		activity,
+		time.Duration(cfg.AffinityLookbackDays)*24*time.Hour,
+	)
+
+	weights := make([]domain.FactorWeight, 0, len(cfg.Weights))
+	for _, w := range cfg.Weights {
+		weights = append(weights, domain.FactorWeight{Name: w.Name, Weight: w.Weight})
+		log.Info("Scoring factor enabled",
+			zap.String("factor", w.Name),
+			zap.Float64("weight", w.Weight),
+		)
+	}
+
+	return registry.Build(weights)
+}
+
+// buildConstraints creates the eligibility constraints checked before scoring
+func buildConstraints(
+	cfg config.EligibilityConfig,
+	capacity domain.CapacityPolicy,
+	normalizer *domain.SkillNormalizer,
+	log *zap.Logger,
+) ([]domain.Constraint, error) {
+	constraints := make([]domain.Constraint, 0, len(cfg.Constraints))
+
+	for _, name := range cfg.Constraints {
+		switch name {
+		case domain.ConstraintRequiredSkills:
+			constraints = append(constraints, domain.RequiredSkillsConstraint{Normalizer: normalizer})
+		case domain.ConstraintCapacity:
+			constraints = append(constraints, domain.CapacityConstraint{Capacity: capacity})
+		case domain.ConstraintBlocklist:
+			constraints = append(constraints, domain.NewBlocklistConstraint(cfg.ProjectBlocklist))
+		default:
+			return nil, fmt.Errorf("unknown constraint: %q", name)
+		}
+
+		log.Info("Eligibility constraint enabled", zap.String("constraint", name))
+	}
+
+	return constraints, nil
+}
+
+// setupRabbitMQ declares exchanges and queues, with retry queues for the consumed ones
+func setupRabbitMQ(
+	conn *rabbitmq.Connection,
+	cfg config.RabbitMQConfig,
+	retry rabbitmq.RetryPolicy,
+	log *zap.Logger,
+) error {
 	log.Info("Setting up RabbitMQ exchanges and queues")
 
+	if err := conn.SetPrefetch(cfg.Prefetch); err != nil {
+		return err
+	}
+
 	if err := conn.DeclareExchange(cfg.Exchange); err != nil {
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	if err := conn.DeclareQueue(cfg.QueueTaskCreated, cfg.Exchange, cfg.QueueTaskCreated); err != nil {
+	if err := conn.DeclareQueue(cfg.QueueTaskCreated, cfg.Exchange, domain.EventTaskCreated); err != nil {
 		return fmt.Errorf("failed to declare task.created queue: %w", err)
 	}
 
-	if err := conn.DeclareQueue(cfg.QueueTaskAssigned, cfg.Exchange, cfg.QueueTaskAssigned); err != nil {
+	if err := conn.DeclareQueue(cfg.QueueTaskAssigned, cfg.Exchange, domain.EventTaskAssigned); err != nil {
 		return fmt.Errorf("failed to declare task.assigned queue: %w", err)
 	}
 
+	err := conn.DeclareQueue(
+		cfg.QueueTaskLifecycle,
+		cfg.Exchange,
+		domain.EventTaskCompleted,
+		domain.EventTaskCancelled,
+		domain.EventTaskReassigned,
+		domain.EventTaskUpdated,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to declare task lifecycle queue: %w", err)
+	}
+
+	for _, queue := range []string{cfg.QueueTaskCreated, cfg.QueueTaskLifecycle} {
+		if err := conn.DeclareRetryTopology(queue, cfg.DeadLetterExchange, retry); err != nil {
+			return fmt.Errorf("failed to declare retry topology for %s: %w", queue, err)
+		}
+	}
+
 	log.Info("RabbitMQ setup completed")
 	return nil
 }
 
-// waitForShutdown waits for interrupt signal and triggers graceful shutdown
-func waitForShutdown(cancel context.CancelFunc, log *zap.Logger) {
+// waitForShutdown waits for interrupt signal
+func waitForShutdown(log *zap.Logger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	<-sigChan
 
 	log.Info("Shutdown signal received, stopping service...")
-	cancel()
 }
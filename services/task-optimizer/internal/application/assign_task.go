@@ -2,69 +2,349 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"task-optimizer/internal/domain"
+	"task-optimizer/pkg/metrics"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.uber.org/zap"
 )
 
-// AssignTaskUseCase orchestrates the task assignment process
+// tracer creates the spans of the use cases
+var tracer = otel.Tracer("task-optimizer/internal/application")
+
+// maxAssignAttempts bounds how often a task is ranked again when its winner lost the slot to a concurrent assignment
+const maxAssignAttempts = 3
+
+// BatchResult reports the outcome of a batch assignment per task.
+// Assigned holds the stored assignments, which differ from the batch's own decision
+// for tasks that were assigned before or concurrently.
+type BatchResult struct {
+	Assigned   []domain.TaskAssignedEvent
+	Unassigned []domain.Task
+	Failed     []TaskFailure
+	TotalScore float64
+}
+
+// TaskFailure is a task of a batch whose assignment could not be stored
+type TaskFailure struct {
+	Task domain.Task
+	Err  error
+}
+
+// AssignTaskUseCase orchestrates the task assignment process.
+// It is safe for concurrent use: assignments to the same user are serialized.
 type AssignTaskUseCase struct {
-	optimizer *domain.OptimizerService
-	userRepo  domain.UserRepository
-	publisher domain.EventPublisher
-	logger    *zap.Logger
+	optimizer      *domain.OptimizerService
+	assignmentRepo domain.AssignmentRepository
+	outbox         domain.OutboxRepository
+	alternatives   int
+	feed           *AssignmentFeed
+	userLocks      *keyedMutex
+	metrics        metrics.Recorder
+	logger         *zap.Logger
 }
 
 // NewAssignTaskUseCase creates a new use case instance
 func NewAssignTaskUseCase(
 	optimizer *domain.OptimizerService,
-	userRepo domain.UserRepository,
-	publisher domain.EventPublisher,
+	assignmentRepo domain.AssignmentRepository,
+	outbox domain.OutboxRepository,
+	alternatives int,
+	feed *AssignmentFeed,
+	recorder metrics.Recorder,
 	logger *zap.Logger,
 ) *AssignTaskUseCase {
 	return &AssignTaskUseCase{
-		optimizer: optimizer,
-		userRepo:  userRepo,
-		publisher: publisher,
-		logger:    logger,
+		optimizer:      optimizer,
+		assignmentRepo: assignmentRepo,
+		outbox:         outbox,
+		alternatives:   alternatives,
+		feed:           feed,
+		userLocks:      newKeyedMutex(),
+		metrics:        recorder,
+		logger:         logger,
 	}
 }
 
-// Execute performs the complete task assignment workflow
-func (uc *AssignTaskUseCase) Execute(ctx context.Context, task domain.Task) error {
+// Execute performs the complete task assignment workflow and returns the stored assignment.
+// A task that was already assigned gets its stored assignment republished instead of a new decision.
+func (uc *AssignTaskUseCase) Execute(ctx context.Context, task domain.Task) (_ *domain.TaskAssignedEvent, err error) {
+	ctx, span := tracer.Start(ctx, "AssignTaskUseCase.Execute")
+	span.SetAttributes(
+		attribute.Int("task.id", task.ID),
+		attribute.Int("task.project_id", task.ProjectID),
+		attribute.Int("task.priority", task.Priority),
+	)
+
+	start := time.Now()
+	defer func() {
+		outcome := assignmentOutcome(err)
+		uc.metrics.AssignmentObserved(outcome, time.Since(start))
+
+		span.SetAttributes(attribute.String("assignment.outcome", outcome))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	uc.logger.Info("Starting task assignment",
 		zap.Int("task_id", task.ID),
 		zap.String("title", task.Title),
 		zap.Int("priority", task.Priority),
-		zap.Strings("skills", task.Skills),
+		zap.Strings("skills", task.SkillNames()),
+		zap.Int("estimated_hours", task.EstimatedHours),
 	)
 
-	result, err := uc.optimizer.FindBestAssignee(ctx, task)
-	if err != nil {
-		uc.logger.Error("Failed to find assignee",
+	existing, err := uc.assignmentRepo.GetAssignment(ctx, task.ID)
+	switch {
+	case err == nil:
+		uc.logger.Info("Task already assigned, republishing stored assignment",
 			zap.Int("task_id", task.ID),
-			zap.Error(err),
+			zap.Int("assignee_id", existing.AssigneeID),
 		)
-		return fmt.Errorf("failed to find assignee: %w", err)
+		if err := uc.republish(ctx, *existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	case !errors.Is(err, domain.ErrAssignmentNotFound):
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	stored, err := uc.assign(ctx, task)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoSuitableUsers) {
+			uc.metrics.NoSuitableUsers(task.ProjectID)
+		}
+		return nil, err
 	}
 
-	uc.logger.Info("Found best assignee",
+	uc.logger.Info("Task assignment completed successfully",
 		zap.Int("task_id", task.ID),
-		zap.Int("user_id", result.UserID),
-		zap.String("user_name", result.UserName),
-		zap.Float64("score", result.TotalScore),
-		zap.String("reason", result.Reason),
+		zap.Int("assignee_id", stored.AssigneeID),
 	)
+	span.SetAttributes(attribute.Int("assignment.assignee_id", stored.AssigneeID))
 
-	if err := uc.userRepo.UpdateUserLoad(ctx, result.UserID, 1); err != nil {
-		uc.logger.Error("Failed to update user load",
+	return stored, nil
+}
+
+// assign ranks the candidates and commits the best one, ranking again when the winner
+// lost the slot to a concurrent assignment. Users rejected on confirmation are left out of
+// later rankings, so a user ranked without free capacity doesn't win every attempt.
+func (uc *AssignTaskUseCase) assign(ctx context.Context, task domain.Task) (*domain.TaskAssignedEvent, error) {
+	var rejected []domain.Exclusion
+
+	for attempt := 1; ; attempt++ {
+		candidates, err := uc.optimizer.RankCandidates(ctx, task, 1+uc.alternatives+len(rejected))
+		if err == nil {
+			candidates = withoutExcluded(candidates, rejected)
+			if len(candidates) == 0 {
+				err = &domain.NoSuitableUsersError{Exclusions: rejected}
+			}
+		}
+		if err != nil {
+			uc.logExclusions(task, err)
+			uc.logger.Error("Failed to find assignee",
+				zap.Int("task_id", task.ID),
+				zap.Error(err),
+			)
+			return nil, fmt.Errorf("failed to find assignee: %w", err)
+		}
+
+		candidates = candidates[:min(len(candidates), 1+uc.alternatives)]
+		result := &candidates[0]
+
+		uc.logger.Info("Found best assignee",
+			zap.Int("task_id", task.ID),
 			zap.Int("user_id", result.UserID),
-			zap.Error(err),
+			zap.String("user_name", result.UserName),
+			zap.Float64("score", result.TotalScore),
+			zap.String("reason", result.Reason),
 		)
+
+		stored, err := uc.commitConfirmed(ctx, task, *result, candidates[1:])
+		if errors.Is(err, domain.ErrCandidateUnavailable) && attempt < maxAssignAttempts {
+			rejected = append(rejected, domain.Exclusion{
+				UserID:   result.UserID,
+				UserName: result.UserName,
+				Reason:   err.Error(),
+			})
+			uc.logger.Warn("Assignee no longer has room for the task, ranking again",
+				zap.Int("task_id", task.ID),
+				zap.Int("user_id", result.UserID),
+				zap.Int("attempt", attempt),
+				zap.Error(err),
+			)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return stored, nil
+	}
+}
+
+// withoutExcluded drops the candidates that were excluded
+func withoutExcluded(candidates []domain.AssignmentResult, exclusions []domain.Exclusion) []domain.AssignmentResult {
+	if len(exclusions) == 0 {
+		return candidates
+	}
+
+	excluded := make(map[int]bool, len(exclusions))
+	for _, exclusion := range exclusions {
+		excluded[exclusion.UserID] = true
+	}
+
+	kept := make([]domain.AssignmentResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !excluded[candidate.UserID] {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
+
+// commitConfirmed commits the assignment while holding the assignee's lock, after checking
+// that assignments committed since ranking left the assignee eligible
+func (uc *AssignTaskUseCase) commitConfirmed(
+	ctx context.Context,
+	task domain.Task,
+	result domain.AssignmentResult,
+	alternatives []domain.AssignmentResult,
+) (*domain.TaskAssignedEvent, error) {
+	unlock := uc.userLocks.Lock(result.UserID)
+	defer unlock()
+
+	if err := uc.optimizer.ConfirmCandidate(ctx, task, result.UserID); err != nil {
+		if errors.Is(err, domain.ErrCandidateUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to confirm assignee: %w", err)
+	}
+
+	return uc.commit(ctx, task, result, alternatives)
+}
+
+// ExecuteBatch assigns several tasks jointly so they are spread over the team.
+// Task IDs must be unique. Tasks that already have an assignment keep it. Every decision is
+// committed under the assignee's lock like a single assignment, with the runner-ups of its
+// matching round, and a task whose assignee lost the slot to a concurrent assignment is ranked
+// again on its own. A task whose assignment cannot be stored is reported as failed without
+// affecting the rest of the batch.
+func (uc *AssignTaskUseCase) ExecuteBatch(ctx context.Context, tasks []domain.Task) (*BatchResult, error) {
+	uc.logger.Info("Starting batch task assignment", zap.Int("tasks", len(tasks)))
+
+	result := &BatchResult{}
+	stored := make(map[int]*domain.TaskAssignedEvent, len(tasks))
+	failed := make(map[int]error)
+	pending := make([]domain.Task, 0, len(tasks))
+
+	for _, task := range tasks {
+		existing, err := uc.assignmentRepo.GetAssignment(ctx, task.ID)
+		switch {
+		case err == nil:
+			uc.logger.Info("Task already assigned, keeping stored assignment",
+				zap.Int("task_id", task.ID),
+				zap.Int("assignee_id", existing.AssigneeID),
+			)
+			stored[task.ID] = existing
+		case errors.Is(err, domain.ErrAssignmentNotFound):
+			pending = append(pending, task)
+		default:
+			failed[task.ID] = fmt.Errorf("failed to get assignment: %w", err)
+		}
+	}
+
+	batch := &domain.BatchAssignment{}
+	if len(pending) > 0 {
+		var err error
+		batch, err = uc.optimizer.BatchAssign(ctx, pending)
+		switch {
+		case errors.Is(err, domain.ErrNoSuitableUsers):
+			batch = &domain.BatchAssignment{Unassigned: pending}
+		case err != nil:
+			uc.logger.Error("Failed to assign batch", zap.Error(err))
+			return nil, fmt.Errorf("failed to assign batch: %w", err)
+		}
 	}
 
+	unassigned := make(map[int]bool, len(batch.Unassigned))
+	for _, task := range batch.Unassigned {
+		unassigned[task.ID] = true
+	}
+
+	for _, assignment := range batch.Assignments {
+		task := assignment.Task
+
+		alternatives := assignment.Alternatives[:min(len(assignment.Alternatives), uc.alternatives)]
+
+		event, err := uc.commitConfirmed(ctx, task, assignment.Result, alternatives)
+		if errors.Is(err, domain.ErrCandidateUnavailable) {
+			uc.logger.Warn("Batch assignee taken by a concurrent assignment, ranking again",
+				zap.Int("task_id", task.ID),
+				zap.Int("user_id", assignment.Result.UserID),
+				zap.Error(err),
+			)
+			event, err = uc.assign(ctx, task)
+		}
+
+		switch {
+		case err == nil:
+			stored[task.ID] = event
+		case errors.Is(err, domain.ErrNoSuitableUsers):
+			unassigned[task.ID] = true
+		default:
+			uc.logger.Error("Failed to commit batch assignment",
+				zap.Int("task_id", task.ID),
+				zap.Error(err),
+			)
+			failed[task.ID] = err
+		}
+	}
+
+	for _, task := range tasks {
+		switch {
+		case stored[task.ID] != nil:
+			result.Assigned = append(result.Assigned, *stored[task.ID])
+			result.TotalScore += stored[task.ID].Score
+		case failed[task.ID] != nil:
+			result.Failed = append(result.Failed, TaskFailure{Task: task, Err: failed[task.ID]})
+		case unassigned[task.ID]:
+			uc.metrics.NoSuitableUsers(task.ProjectID)
+			uc.logger.Warn("Task left unassigned in batch",
+				zap.Int("task_id", task.ID),
+				zap.String("title", task.Title),
+			)
+			result.Unassigned = append(result.Unassigned, task)
+		}
+	}
+
+	uc.logger.Info("Batch task assignment completed",
+		zap.Int("assigned", len(result.Assigned)),
+		zap.Int("unassigned", len(result.Unassigned)),
+		zap.Int("failed", len(result.Failed)),
+		zap.Float64("total_score", result.TotalScore),
+	)
+
+	return result, nil
+}
+
+// commit stores the decided assignment, books the assignee's capacity and queues the assignment event
+// in one transaction. When another delivery of the task stored its assignment first, that assignment wins
+// and is returned instead.
+func (uc *AssignTaskUseCase) commit(
+	ctx context.Context,
+	task domain.Task,
+	result domain.AssignmentResult,
+	alternatives []domain.AssignmentResult,
+) (*domain.TaskAssignedEvent, error) {
 	event := domain.TaskAssignedEvent{
 		TaskID:     task.ID,
 		AssigneeID: result.UserID,
@@ -73,18 +353,83 @@ func (uc *AssignTaskUseCase) Execute(ctx context.Context, task domain.Task) erro
 		AssignedAt: time.Now(),
 	}
 
-	if err := uc.publisher.PublishTaskAssigned(ctx, event); err != nil {
-		uc.logger.Error("Failed to publish event",
+	for _, alternative := range alternatives {
+		event.Alternatives = append(event.Alternatives, alternative.Summary())
+	}
+
+	load := domain.LoadEntry{
+		TaskID:         task.ID,
+		UserID:         result.UserID,
+		EstimatedHours: task.EstimatedHours,
+	}
+
+	stored, created, err := uc.assignmentRepo.CreateAssignment(ctx, event, load)
+	if err != nil {
+		uc.logger.Error("Failed to store assignment",
 			zap.Int("task_id", task.ID),
 			zap.Error(err),
 		)
-		return fmt.Errorf("failed to publish event: %w", err)
+		return nil, fmt.Errorf("failed to store assignment: %w", err)
 	}
 
-	uc.logger.Info("Task assignment completed successfully",
-		zap.Int("task_id", task.ID),
-		zap.Int("assignee_id", result.UserID),
-	)
+	if !created {
+		uc.logger.Warn("Task was assigned concurrently, keeping stored assignment",
+			zap.Int("task_id", task.ID),
+			zap.Int("assignee_id", stored.AssigneeID),
+			zap.Int("discarded_assignee_id", event.AssigneeID),
+		)
+		return stored, nil
+	}
+
+	uc.metrics.AssignmentScored(stored.Score)
+	uc.feed.Publish(*stored)
+
+	return stored, nil
+}
+
+// republish queues the stored assignment event of a task again
+func (uc *AssignTaskUseCase) republish(ctx context.Context, event domain.TaskAssignedEvent) error {
+	message, err := domain.NewTaskAssignedMessage(event)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.outbox.Enqueue(ctx, message); err != nil {
+		uc.logger.Error("Failed to enqueue event",
+			zap.Int("task_id", event.TaskID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
 
 	return nil
 }
+
+// assignmentOutcome classifies the result of an assignment for metrics
+func assignmentOutcome(err error) string {
+	switch {
+	case err == nil:
+		return metrics.OutcomeAssigned
+	case errors.Is(err, domain.ErrNoSuitableUsers):
+		return metrics.OutcomeNoSuitableUsers
+	default:
+		return metrics.OutcomeError
+	}
+}
+
+// logExclusions logs why each user was ineligible when nobody was left
+func (uc *AssignTaskUseCase) logExclusions(task domain.Task, err error) {
+	var noUsers *domain.NoSuitableUsersError
+	if !errors.As(err, &noUsers) {
+		return
+	}
+
+	for _, exclusion := range noUsers.Exclusions {
+		uc.logger.Warn("User excluded from assignment",
+			zap.Int("task_id", task.ID),
+			zap.Int("user_id", exclusion.UserID),
+			zap.String("user_name", exclusion.UserName),
+			zap.String("reason", exclusion.Reason),
+		)
+	}
+}
@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"task-optimizer/internal/domain"
+	"task-optimizer/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockAssignmentRepository is a mock implementation of domain.AssignmentRepository.
+// CreateAssignment stores the given event unless the expectation returns another one.
+type MockAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentRepository) GetAssignment(ctx context.Context, taskID int) (*domain.TaskAssignedEvent, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskAssignedEvent), args.Error(1)
+}
+
+func (m *MockAssignmentRepository) CreateAssignment(
+	ctx context.Context,
+	event domain.TaskAssignedEvent,
+	load domain.LoadEntry,
+) (*domain.TaskAssignedEvent, bool, error) {
+	args := m.Called(ctx, event, load)
+	if err := args.Error(2); err != nil {
+		return nil, false, err
+	}
+	if stored, ok := args.Get(0).(*domain.TaskAssignedEvent); ok {
+		return stored, args.Bool(1), nil
+	}
+	return &event, true, nil
+}
+
+// recordingMetrics is a metrics.Recorder remembering the scored assignments
+type recordingMetrics struct {
+	metrics.Nop
+	scored []float64
+}
+
+func (r *recordingMetrics) AssignmentScored(score float64) { r.scored = append(r.scored, score) }
+
+// assignedTo matches a decided assignment of the task to the user
+func assignedTo(taskID, userID int) interface{} {
+	return mock.MatchedBy(func(event domain.TaskAssignedEvent) bool {
+		return event.TaskID == taskID && event.AssigneeID == userID
+	})
+}
+
+type assignFixture struct {
+	users       *MockUserRepository
+	assignments *MockAssignmentRepository
+	outbox      *MockOutboxRepository
+	feed        *AssignmentFeed
+}
+
+func newAssignFixture() *assignFixture {
+	return &assignFixture{
+		users:       new(MockUserRepository),
+		assignments: new(MockAssignmentRepository),
+		outbox:      new(MockOutboxRepository),
+		feed:        NewAssignmentFeed(zap.NewNop()),
+	}
+}
+
+func (f *assignFixture) useCase(recorder metrics.Recorder) *AssignTaskUseCase {
+	capacity := domain.DefaultCapacityPolicy()
+	optimizer := domain.NewOptimizerService(f.users,
+		domain.WithCapacity(capacity),
+		domain.WithConstraints(domain.CapacityConstraint{Capacity: capacity}),
+	)
+	return NewAssignTaskUseCase(optimizer, f.assignments, f.outbox, 1, f.feed, recorder, zap.NewNop())
+}
+
+func TestAssignTaskExecute(t *testing.T) {
+	ctx := context.Background()
+	task := domain.Task{ID: 10, Title: "Fix login", Priority: 3, ProjectID: 1, Skills: domain.RequirementsFromNames("go")}
+
+	alice := domain.User{ID: 1, Name: "Alice", Skills: domain.SkillsFromNames("go"), CurrentLoad: 0, MaxCapacity: 10}
+	bob := domain.User{ID: 2, Name: "Bob", Skills: domain.SkillsFromNames("go"), CurrentLoad: 5, MaxCapacity: 10}
+	full := func(user domain.User) *domain.User {
+		user.CurrentLoad = user.MaxCapacity
+		return &user
+	}
+
+	t.Run("republishes the stored assignment of a redelivered task", func(t *testing.T) {
+		f := newAssignFixture()
+		stored := &domain.TaskAssignedEvent{
+			TaskID:     10,
+			AssigneeID: 2,
+			Score:      0.8,
+			AssignedAt: time.Date(2025, 11, 3, 9, 30, 0, 0, time.UTC),
+		}
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(stored, nil)
+
+		var republished domain.OutboxMessage
+		f.outbox.On("Enqueue", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { republished = args.Get(1).(domain.OutboxMessage) }).
+			Return(nil)
+
+		event, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, event)
+		assert.Equal(t, domain.EventTaskAssigned, republished.RoutingKey)
+
+		var payload domain.TaskAssignedEvent
+		require.NoError(t, json.Unmarshal(republished.Payload, &payload))
+		assert.Equal(t, 2, payload.AssigneeID)
+		assert.True(t, stored.AssignedAt.Equal(payload.AssignedAt))
+
+		f.users.AssertNotCalled(t, "GetActiveUsers", mock.Anything)
+		f.assignments.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed republish fails the delivery", func(t *testing.T) {
+		f := newAssignFixture()
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(&domain.TaskAssignedEvent{TaskID: 10, AssigneeID: 2}, nil)
+		f.outbox.On("Enqueue", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		_, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		assert.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("stores a new assignment with runner-ups", func(t *testing.T) {
+		f := newAssignFixture()
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		f.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{alice, bob}, nil)
+		f.users.On("GetUserByID", mock.Anything, 1).Return(&alice, nil)
+		f.assignments.On("CreateAssignment", mock.Anything, assignedTo(10, 1), domain.LoadEntry{TaskID: 10, UserID: 1}).
+			Return(nil, true, nil)
+
+		events, unsubscribe := f.feed.Subscribe()
+		defer unsubscribe()
+
+		event, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, event.AssigneeID)
+		require.Len(t, event.Alternatives, 1)
+		assert.Equal(t, 2, event.Alternatives[0].UserID)
+		assert.Equal(t, 1, (<-events).AssigneeID, "committed assignment is broadcast")
+		f.assignments.AssertExpectations(t)
+	})
+
+	t.Run("keeps the assignment stored by a concurrent delivery", func(t *testing.T) {
+		f := newAssignFixture()
+		winner := &domain.TaskAssignedEvent{TaskID: 10, AssigneeID: 2, Score: 0.6, AssignedAt: time.Now()}
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		f.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{alice, bob}, nil)
+		f.users.On("GetUserByID", mock.Anything, 1).Return(&alice, nil)
+		f.assignments.On("CreateAssignment", mock.Anything, assignedTo(10, 1), mock.Anything).Return(winner, false, nil)
+
+		recorder := &recordingMetrics{}
+		events, unsubscribe := f.feed.Subscribe()
+		defer unsubscribe()
+
+		event, err := f.useCase(recorder).Execute(ctx, task)
+
+		require.NoError(t, err)
+		assert.Equal(t, winner, event)
+		assert.Empty(t, recorder.scored, "the discarded decision is not scored")
+		assert.Empty(t, events, "the discarded decision is not broadcast")
+	})
+
+	t.Run("ranks again when the winner lost the slot", func(t *testing.T) {
+		f := newAssignFixture()
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		// The ranking still sees Alice's old load while a concurrent assignment filled her up
+		f.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{alice, bob}, nil)
+		f.users.On("GetUserByID", mock.Anything, 1).Return(full(alice), nil)
+		f.users.On("GetUserByID", mock.Anything, 2).Return(&bob, nil)
+		f.assignments.On("CreateAssignment", mock.Anything, assignedTo(10, 2), mock.Anything).Return(nil, true, nil)
+
+		event, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, event.AssigneeID)
+		f.users.AssertNumberOfCalls(t, "GetActiveUsers", 2)
+		f.assignments.AssertNotCalled(t, "CreateAssignment", mock.Anything, assignedTo(10, 1), mock.Anything)
+	})
+
+	t.Run("reports no suitable users when every candidate lost the slot", func(t *testing.T) {
+		f := newAssignFixture()
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		f.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{alice}, nil)
+		f.users.On("GetUserByID", mock.Anything, 1).Return(full(alice), nil)
+
+		_, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		var noUsers *domain.NoSuitableUsersError
+		require.ErrorAs(t, err, &noUsers)
+		require.Len(t, noUsers.Exclusions, 1)
+		assert.Equal(t, 1, noUsers.Exclusions[0].UserID)
+		assert.Contains(t, noUsers.Exclusions[0].Reason, "no capacity")
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		f := newAssignFixture()
+		users := []domain.User{alice, bob, {ID: 3, Name: "Carol", Skills: domain.SkillsFromNames("go"), MaxCapacity: 10}}
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		f.users.On("GetActiveUsers", mock.Anything).Return(users, nil)
+		for _, user := range users {
+			f.users.On("GetUserByID", mock.Anything, user.ID).Return(full(user), nil)
+		}
+
+		_, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		assert.ErrorIs(t, err, domain.ErrCandidateUnavailable)
+		f.users.AssertNumberOfCalls(t, "GetUserByID", maxAssignAttempts)
+		f.assignments.AssertNotCalled(t, "CreateAssignment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed lookup of the stored assignment fails the delivery", func(t *testing.T) {
+		f := newAssignFixture()
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, errors.New("connection reset"))
+
+		_, err := f.useCase(metrics.Nop{}).Execute(ctx, task)
+
+		assert.ErrorContains(t, err, "failed to get assignment")
+		f.users.AssertNotCalled(t, "GetActiveUsers", mock.Anything)
+	})
+}
+
+func TestAssignTaskExecuteBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores batch assignments with runner-ups", func(t *testing.T) {
+		f := newAssignFixture()
+		alice := domain.User{ID: 1, Name: "Alice", Skills: domain.SkillsFromNames("go"), MaxCapacity: 10}
+		bob := domain.User{ID: 2, Name: "Bob", Skills: domain.SkillsFromNames("go"), CurrentLoad: 5, MaxCapacity: 10}
+		task := domain.Task{ID: 10, Priority: 3, Skills: domain.RequirementsFromNames("go")}
+
+		f.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		f.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{alice, bob}, nil)
+		f.users.On("GetUserByID", mock.Anything, 1).Return(&alice, nil)
+		f.assignments.On("CreateAssignment", mock.Anything, assignedTo(10, 1), mock.Anything).Return(nil, true, nil)
+
+		batch, err := f.useCase(metrics.Nop{}).ExecuteBatch(ctx, []domain.Task{task})
+
+		require.NoError(t, err)
+		require.Len(t, batch.Assigned, 1)
+		require.Len(t, batch.Assigned[0].Alternatives, 1)
+		assert.Equal(t, 2, batch.Assigned[0].Alternatives[0].UserID)
+	})
+}
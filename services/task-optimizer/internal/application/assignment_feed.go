@@ -0,0 +1,80 @@
+package application
+
+import (
+	"sync"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// feedBuffer is how many assignments a subscriber may lag behind before it misses some
+const feedBuffer = 64
+
+// AssignmentFeed broadcasts the assignments committed by this process to its subscribers.
+// A subscriber that does not keep up misses assignments instead of slowing down assignment.
+type AssignmentFeed struct {
+	mu          sync.Mutex
+	subscribers map[chan domain.TaskAssignedEvent]struct{}
+	closed      bool
+	logger      *zap.Logger
+}
+
+// NewAssignmentFeed creates an assignment feed without subscribers
+func NewAssignmentFeed(logger *zap.Logger) *AssignmentFeed {
+	return &AssignmentFeed{
+		subscribers: make(map[chan domain.TaskAssignedEvent]struct{}),
+		logger:      logger,
+	}
+}
+
+// Subscribe returns a channel receiving every assignment committed from now on and the
+// function ending the subscription. The channel is closed when the feed is closed.
+func (f *AssignmentFeed) Subscribe() (<-chan domain.TaskAssignedEvent, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	events := make(chan domain.TaskAssignedEvent, feedBuffer)
+	if f.closed {
+		close(events)
+		return events, func() {}
+	}
+	f.subscribers[events] = struct{}{}
+
+	return events, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if _, ok := f.subscribers[events]; ok {
+			delete(f.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// Publish hands the assignment to every subscriber
+func (f *AssignmentFeed) Publish(event domain.TaskAssignedEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for events := range f.subscribers {
+		select {
+		case events <- event:
+		default:
+			f.logger.Warn("Assignment feed subscriber is lagging, dropping assignment",
+				zap.Int("task_id", event.TaskID),
+			)
+		}
+	}
+}
+
+// Close ends every subscription
+func (f *AssignmentFeed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for events := range f.subscribers {
+		delete(f.subscribers, events)
+		close(events)
+	}
+}
@@ -0,0 +1,44 @@
+package application
+
+import "sync"
+
+// keyedMutex serializes work per key while different keys proceed in parallel.
+// Entries are dropped once nobody holds or waits for them.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[int]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[int]*keyedLock)}
+}
+
+// Lock locks the key and returns the function unlocking it
+func (k *keyedMutex) Lock(key int) func() {
+	k.mu.Lock()
+	lock, ok := k.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		k.locks[key] = lock
+	}
+	lock.refs++
+	k.mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		k.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
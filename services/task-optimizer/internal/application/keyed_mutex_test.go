@@ -0,0 +1,90 @@
+package application
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	t.Run("serializes the same key", func(t *testing.T) {
+		locks := newKeyedMutex()
+		unlock := locks.Lock(1)
+
+		acquired := make(chan struct{})
+		go func() {
+			defer locks.Lock(1)()
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("second holder acquired a locked key")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		unlock()
+		<-acquired
+	})
+
+	t.Run("different keys do not block each other", func(t *testing.T) {
+		locks := newKeyedMutex()
+		unlock := locks.Lock(1)
+		defer unlock()
+
+		acquired := make(chan struct{})
+		go func() {
+			defer locks.Lock(2)()
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("lock on another key blocked")
+		}
+	})
+
+	t.Run("drops entries nobody holds or waits for", func(t *testing.T) {
+		locks := newKeyedMutex()
+
+		var wg sync.WaitGroup
+		for worker := 0; worker < 20; worker++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					locks.Lock(worker % 3)()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Empty(t, locks.locks)
+	})
+
+	t.Run("keeps the entry while someone waits", func(t *testing.T) {
+		locks := newKeyedMutex()
+		unlock := locks.Lock(1)
+
+		waiting := make(chan func())
+		go func() { waiting <- locks.Lock(1) }()
+
+		// Wait until the second holder has registered on the key
+		assert.Eventually(t, func() bool {
+			locks.mu.Lock()
+			defer locks.mu.Unlock()
+			return locks.locks[1] != nil && locks.locks[1].refs == 2
+		}, time.Second, time.Millisecond)
+
+		unlock()
+		locks.mu.Lock()
+		assert.Contains(t, locks.locks, 1, "entry is kept for the waiting holder")
+		locks.mu.Unlock()
+
+		(<-waiting)()
+		assert.Empty(t, locks.locks)
+	})
+}
@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+)
+
+// OutboxRelay publishes pending outbox messages and marks them sent once the broker confirms them
+type OutboxRelay struct {
+	outbox        domain.OutboxRepository
+	publisher     domain.MessagePublisher
+	interval      time.Duration
+	batchSize     int
+	timeout       time.Duration
+	maxRetryDelay time.Duration
+	logger        *zap.Logger
+}
+
+// NewOutboxRelay creates a relay polling the outbox every interval.
+// Each publish waits at most timeout for the broker confirm. A message that failed
+// is retried after a delay doubling with every attempt, up to maxRetryDelay.
+func NewOutboxRelay(
+	outbox domain.OutboxRepository,
+	publisher domain.MessagePublisher,
+	interval time.Duration,
+	batchSize int,
+	timeout time.Duration,
+	maxRetryDelay time.Duration,
+	logger *zap.Logger,
+) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:        outbox,
+		publisher:     publisher,
+		interval:      interval,
+		batchSize:     batchSize,
+		timeout:       timeout,
+		maxRetryDelay: maxRetryDelay,
+		logger:        logger,
+	}
+}
+
+// Run relays messages until the context is cancelled.
+// A batch already claimed is finished first, so its messages are not left leased.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	r.logger.Info("Outbox relay started",
+		zap.Duration("interval", r.interval),
+		zap.Int("batch_size", r.batchSize),
+	)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// A fully sent batch means more messages are probably waiting, so skip the wait
+		if r.relayBatch(context.WithoutCancel(ctx)) == r.batchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush relays pending messages until a batch is not fully sent or the context is done.
+// It is called on shutdown, after Run returned, to publish events of the last handled messages.
+func (r *OutboxRelay) Flush(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to flush outbox: %w", err)
+		}
+		if r.relayBatch(ctx) < r.batchSize {
+			return nil
+		}
+	}
+}
+
+// relayBatch publishes one batch of pending messages and returns how many were sent
+func (r *OutboxRelay) relayBatch(ctx context.Context) int {
+	// A claimed message is retried by another relay once the lease expires
+	lease := 2*r.interval + 30*time.Second
+
+	messages, err := r.outbox.ClaimPending(ctx, r.batchSize, lease)
+	if err != nil {
+		r.logger.Error("Failed to claim outbox messages", zap.Error(err))
+		return 0
+	}
+
+	sent := 0
+	for _, message := range messages {
+		if err := r.publish(ctx, message); err != nil {
+			r.logFailure(message, err)
+
+			// Backing off keeps failing messages from taking every claim away from newer ones
+			if err := r.outbox.MarkFailed(ctx, message.ID, err.Error(), r.retryDelay(message.Attempts)); err != nil {
+				r.logger.Error("Failed to mark outbox message failed",
+					zap.Int64("outbox_id", message.ID),
+					zap.Error(err),
+				)
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkSent(ctx, message.ID); err != nil {
+			r.logger.Error("Failed to mark outbox message sent",
+				zap.Int64("outbox_id", message.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		r.logger.Info("Relayed outbox message",
+			zap.Int64("outbox_id", message.ID),
+			zap.String("routing_key", message.RoutingKey),
+		)
+		sent++
+	}
+
+	return sent
+}
+
+// retryDelay returns how long a message waits after its given failed attempt, counting from one
+func (r *OutboxRelay) retryDelay(attempts int) time.Duration {
+	delay := r.interval
+	for i := 1; i < attempts && delay < r.maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxRetryDelay)
+}
+
+// publish publishes one message, waiting at most the relay timeout for its confirm
+func (r *OutboxRelay) publish(ctx context.Context, message domain.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// Publish within the trace of the operation that queued the message
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(message.TraceContext))
+
+	return r.publisher.Publish(ctx, message.RoutingKey, message.Payload)
+}
+
+// logFailure logs a failed publish. The message stays pending either way, but an unroutable
+// message points at missing topology rather than a broker hiccup.
+func (r *OutboxRelay) logFailure(message domain.OutboxMessage, err error) {
+	fields := []zap.Field{
+		zap.Int64("outbox_id", message.ID),
+		zap.String("routing_key", message.RoutingKey),
+		zap.Int("attempts", message.Attempts),
+		zap.Error(err),
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrMessageUnroutable):
+		r.logger.Error("Outbox message is unroutable, no queue is bound for its routing key", fields...)
+	case errors.Is(err, domain.ErrMessageNacked), errors.Is(err, domain.ErrConfirmTimeout):
+		r.logger.Warn("Outbox message was not confirmed by broker", fields...)
+	default:
+		r.logger.Error("Failed to relay outbox message", fields...)
+	}
+}
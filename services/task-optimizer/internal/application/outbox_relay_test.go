@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-optimizer/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockOutboxRepository is a mock implementation of domain.OutboxRepository
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxMessage, error) {
+	args := m.Called(ctx, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string, retryAfter time.Duration) error {
+	args := m.Called(ctx, id, reason, retryAfter)
+	return args.Error(0)
+}
+
+// MockMessagePublisher is a mock implementation of domain.MessagePublisher
+type MockMessagePublisher struct {
+	mock.Mock
+}
+
+func (m *MockMessagePublisher) Publish(ctx context.Context, routingKey string, body []byte) error {
+	args := m.Called(ctx, routingKey, body)
+	return args.Error(0)
+}
+
+func outboxMessage(id int64, attempts int) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		ID:         id,
+		RoutingKey: domain.EventTaskAssigned,
+		Payload:    []byte(fmt.Sprintf(`{"task_id":%d}`, id)),
+		Attempts:   attempts,
+	}
+}
+
+func newTestRelay(outbox *MockOutboxRepository, publisher *MockMessagePublisher, batchSize int) *OutboxRelay {
+	return NewOutboxRelay(outbox, publisher, time.Second, batchSize, time.Second, 10*time.Second, zap.NewNop())
+}
+
+func TestOutboxRelayBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("marks confirmed messages sent and backs off failed ones", func(t *testing.T) {
+		outbox := new(MockOutboxRepository)
+		publisher := new(MockMessagePublisher)
+		relay := newTestRelay(outbox, publisher, 10)
+
+		sent, unroutable, unmarked := outboxMessage(1, 1), outboxMessage(2, 3), outboxMessage(3, 1)
+		outbox.On("ClaimPending", ctx, 10, mock.Anything).
+			Return([]domain.OutboxMessage{sent, unroutable, unmarked}, nil)
+
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, sent.Payload).Return(nil)
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, unroutable.Payload).
+			Return(&domain.PublishError{RoutingKey: domain.EventTaskAssigned, Err: domain.ErrMessageUnroutable})
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, unmarked.Payload).Return(nil)
+
+		outbox.On("MarkSent", ctx, int64(1)).Return(nil)
+		outbox.On("MarkFailed", ctx, int64(2), mock.Anything, 4*time.Second).Return(nil)
+		outbox.On("MarkSent", ctx, int64(3)).Return(errors.New("connection reset"))
+
+		assert.Equal(t, 1, relay.relayBatch(ctx), "a message not marked sent is not counted")
+		outbox.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("claim failure sends nothing", func(t *testing.T) {
+		outbox := new(MockOutboxRepository)
+		publisher := new(MockMessagePublisher)
+		relay := newTestRelay(outbox, publisher, 10)
+		outbox.On("ClaimPending", ctx, 10, mock.Anything).Return(nil, errors.New("connection reset"))
+
+		assert.Zero(t, relay.relayBatch(ctx))
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("publish waits at most the timeout for the confirm", func(t *testing.T) {
+		outbox := new(MockOutboxRepository)
+		publisher := new(MockMessagePublisher)
+		relay := newTestRelay(outbox, publisher, 10)
+
+		outbox.On("ClaimPending", ctx, 10, mock.Anything).Return([]domain.OutboxMessage{outboxMessage(1, 1)}, nil)
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, mock.Anything).
+			Run(func(args mock.Arguments) {
+				deadline, ok := args.Get(0).(context.Context).Deadline()
+				assert.True(t, ok)
+				assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+			}).
+			Return(nil)
+		outbox.On("MarkSent", ctx, int64(1)).Return(nil)
+
+		relay.relayBatch(ctx)
+		publisher.AssertExpectations(t)
+	})
+}
+
+func TestOutboxRelayRetryDelay(t *testing.T) {
+	relay := newTestRelay(nil, nil, 10)
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: time.Second},
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 4, expected: 8 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 100, expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, relay.retryDelay(tt.attempts), "attempts: %d", tt.attempts)
+	}
+}
+
+func TestOutboxRelayFlush(t *testing.T) {
+	t.Run("relays until a batch is not full", func(t *testing.T) {
+		ctx := context.Background()
+		outbox := new(MockOutboxRepository)
+		publisher := new(MockMessagePublisher)
+		relay := newTestRelay(outbox, publisher, 2)
+
+		outbox.On("ClaimPending", ctx, 2, mock.Anything).
+			Return([]domain.OutboxMessage{outboxMessage(1, 1), outboxMessage(2, 1)}, nil).Once()
+		outbox.On("ClaimPending", ctx, 2, mock.Anything).
+			Return([]domain.OutboxMessage{outboxMessage(3, 1)}, nil).Once()
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, mock.Anything).Return(nil)
+		outbox.On("MarkSent", ctx, mock.Anything).Return(nil)
+
+		require.NoError(t, relay.Flush(ctx))
+		outbox.AssertNumberOfCalls(t, "ClaimPending", 2)
+		outbox.AssertNumberOfCalls(t, "MarkSent", 3)
+	})
+
+	t.Run("stops when failures keep the batch from filling", func(t *testing.T) {
+		ctx := context.Background()
+		outbox := new(MockOutboxRepository)
+		publisher := new(MockMessagePublisher)
+		relay := newTestRelay(outbox, publisher, 1)
+
+		outbox.On("ClaimPending", ctx, 1, mock.Anything).Return([]domain.OutboxMessage{outboxMessage(1, 1)}, nil).Once()
+		publisher.On("Publish", mock.Anything, domain.EventTaskAssigned, mock.Anything).
+			Return(&domain.PublishError{RoutingKey: domain.EventTaskAssigned, Err: domain.ErrMessageNacked})
+		outbox.On("MarkFailed", ctx, int64(1), mock.Anything, time.Second).Return(nil)
+
+		require.NoError(t, relay.Flush(ctx))
+		outbox.AssertExpectations(t)
+	})
+
+	t.Run("gives up when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		outbox := new(MockOutboxRepository)
+		relay := newTestRelay(outbox, new(MockMessagePublisher), 2)
+
+		err := relay.Flush(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		outbox.AssertNotCalled(t, "ClaimPending", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
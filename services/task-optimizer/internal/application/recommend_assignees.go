@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Recommendation holds the ranked candidates for a task.
+// When nobody is eligible, Exclusions explains why each user was left out.
+type Recommendation struct {
+	Candidates []domain.AssignmentResult
+	Exclusions []domain.Exclusion
+}
+
+// RecommendAssigneesUseCase ranks and explains candidates for a task without assigning it
+type RecommendAssigneesUseCase struct {
+	optimizer *domain.OptimizerService
+	logger    *zap.Logger
+}
+
+// NewRecommendAssigneesUseCase creates a new use case instance
+func NewRecommendAssigneesUseCase(optimizer *domain.OptimizerService, logger *zap.Logger) *RecommendAssigneesUseCase {
+	return &RecommendAssigneesUseCase{
+		optimizer: optimizer,
+		logger:    logger,
+	}
+}
+
+// Execute returns up to limit candidates ordered from best to worst.
+// Nothing is stored or published.
+func (uc *RecommendAssigneesUseCase) Execute(ctx context.Context, task domain.Task, limit int) (*Recommendation, error) {
+	candidates, err := uc.optimizer.RankCandidates(ctx, task, limit)
+
+	var noUsers *domain.NoSuitableUsersError
+	switch {
+	case errors.As(err, &noUsers):
+		return &Recommendation{Exclusions: noUsers.Exclusions}, nil
+	case errors.Is(err, domain.ErrNoSuitableUsers):
+		return &Recommendation{}, nil
+	case err != nil:
+		uc.logger.Error("Failed to rank candidates",
+			zap.Int("project_id", task.ProjectID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to rank candidates: %w", err)
+	}
+
+	uc.logger.Debug("Recommended assignees",
+		zap.Int("project_id", task.ProjectID),
+		zap.Strings("skills", task.SkillNames()),
+		zap.Int("candidates", len(candidates)),
+	)
+
+	return &Recommendation{Candidates: candidates}, nil
+}
+
+// Explain reports the score and rank of one user for the task, or why the user is not a candidate
+func (uc *RecommendAssigneesUseCase) Explain(ctx context.Context, task domain.Task, userID int) (*domain.Explanation, error) {
+	explanation, err := uc.optimizer.ExplainCandidate(ctx, task, userID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			uc.logger.Error("Failed to explain candidate",
+				zap.Int("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, fmt.Errorf("failed to explain candidate: %w", err)
+	}
+
+	return explanation, nil
+}
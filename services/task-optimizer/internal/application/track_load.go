@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// TrackLoadUseCase keeps the load ledger in sync with the task lifecycle
+type TrackLoadUseCase struct {
+	userRepo domain.UserRepository
+	logger   *zap.Logger
+}
+
+// NewTrackLoadUseCase creates a new use case instance
+func NewTrackLoadUseCase(userRepo domain.UserRepository, logger *zap.Logger) *TrackLoadUseCase {
+	return &TrackLoadUseCase{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// Release frees the capacity of a completed or cancelled task.
+// Tasks that were never booked, e.g. assigned manually in Laravel, or were already released
+// by an earlier delivery have nothing to release.
+func (uc *TrackLoadUseCase) Release(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	userID, err := uc.userRepo.ReleaseUserLoad(ctx, event.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to release task %d: %w", event.TaskID, err)
+	}
+
+	if userID == 0 {
+		uc.logger.Debug("Task load not booked, nothing to release",
+			zap.Int("task_id", event.TaskID),
+			zap.String("status", event.Status),
+		)
+		return nil
+	}
+
+	uc.logger.Info("Task load released",
+		zap.Int("task_id", event.TaskID),
+		zap.Int("user_id", userID),
+		zap.String("status", event.Status),
+	)
+
+	return nil
+}
+
+// Rebook moves the task to its current assignee with its current estimate.
+// A task left without assignee releases its capacity.
+func (uc *TrackLoadUseCase) Rebook(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	if event.AssigneeID <= 0 {
+		return uc.Release(ctx, event)
+	}
+
+	if err := uc.userRepo.UpdateUserLoad(ctx, event.LoadEntry()); err != nil {
+		return fmt.Errorf("failed to rebook task %d: %w", event.TaskID, err)
+	}
+
+	uc.logger.Info("Task load rebooked",
+		zap.Int("task_id", event.TaskID),
+		zap.Int("user_id", event.AssigneeID),
+		zap.Int("previous_user_id", event.PreviousAssigneeID),
+		zap.Int("estimated_hours", event.EstimatedHours),
+	)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"task-optimizer/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockUserRepository is a mock implementation of domain.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserLoad(ctx context.Context, entry domain.LoadEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReleaseUserLoad(ctx context.Context, taskID int) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTrackLoadRelease(t *testing.T) {
+	ctx := context.Background()
+	event := domain.TaskLifecycleEvent{TaskID: 10, AssigneeID: 3, Status: "completed"}
+
+	tests := []struct {
+		name   string
+		userID int
+		err    error
+	}{
+		{name: "booked task is released", userID: 3},
+		{name: "task never booked has nothing to release", userID: 0},
+		{name: "failed release fails the event", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			userRepo.On("ReleaseUserLoad", ctx, 10).Return(tt.userID, tt.err)
+
+			err := NewTrackLoadUseCase(userRepo, zap.NewNop()).Release(ctx, event)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.ErrorContains(t, err, "task 10")
+			} else {
+				assert.NoError(t, err)
+			}
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTrackLoadRebook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("books the task on its current assignee with its current estimate", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("UpdateUserLoad", ctx, domain.LoadEntry{TaskID: 10, UserID: 4, EstimatedHours: 6}).Return(nil)
+
+		err := NewTrackLoadUseCase(userRepo, zap.NewNop()).Rebook(ctx, domain.TaskLifecycleEvent{
+			TaskID:             10,
+			AssigneeID:         4,
+			PreviousAssigneeID: 3,
+			EstimatedHours:     6,
+		})
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("task left without assignee releases its capacity", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("ReleaseUserLoad", ctx, 10).Return(3, nil)
+
+		err := NewTrackLoadUseCase(userRepo, zap.NewNop()).Rebook(ctx, domain.TaskLifecycleEvent{
+			TaskID:             10,
+			PreviousAssigneeID: 3,
+		})
+
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		userRepo.AssertNotCalled(t, "UpdateUserLoad", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed booking fails the event", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		failure := errors.New("connection reset")
+		userRepo.On("UpdateUserLoad", ctx, mock.Anything).Return(failure)
+
+		err := NewTrackLoadUseCase(userRepo, zap.NewNop()).Rebook(ctx, domain.TaskLifecycleEvent{TaskID: 10, AssigneeID: 4})
+
+		assert.ErrorIs(t, err, failure)
+	})
+}
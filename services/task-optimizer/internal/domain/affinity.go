@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FactorAffinity is the name of the project affinity scoring factor
+const FactorAffinity = "affinity"
+
+// affinityHalfTasks is the number of project tasks that gives half of the affinity score
+const affinityHalfTasks = 2
+
+// ProjectActivity is how involved a user is in a project
+type ProjectActivity struct {
+	OpenTasks      int
+	CompletedTasks int
+}
+
+// Tasks returns the number of open and recently completed tasks
+func (a ProjectActivity) Tasks() int {
+	return a.OpenTasks + a.CompletedTasks
+}
+
+// AffinityScorer favors users who already work on the task's project, keeping context within a team.
+// The score grows with the number of open and recently completed project tasks and saturates at 1.0.
+type AffinityScorer struct {
+	repo     ProjectActivityRepository
+	lookback time.Duration
+	now      func() time.Time
+}
+
+// NewAffinityScorer creates an affinity scorer counting tasks completed within lookback
+func NewAffinityScorer(repo ProjectActivityRepository, lookback time.Duration) *AffinityScorer {
+	return &AffinityScorer{
+		repo:     repo,
+		lookback: lookback,
+		now:      time.Now,
+	}
+}
+
+// Score implements Scorer. Without prepared activity nobody has affinity.
+func (s *AffinityScorer) Score(_ Task, _ User) (float64, string) {
+	return 0.0, ""
+}
+
+// Prepare implements Preparer by loading the candidates' activity in the task's project
+func (s *AffinityScorer) Prepare(ctx context.Context, task Task, users []User) (Scorer, error) {
+	if task.ProjectID == 0 {
+		return s, nil
+	}
+
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	activity, err := s.repo.GetProjectActivity(ctx, task.ProjectID, userIDs, s.now().Add(-s.lookback))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project activity: %w", err)
+	}
+
+	return preparedAffinityScorer{activity: activity}, nil
+}
+
+// preparedAffinityScorer scores users against project activity loaded for one task
+type preparedAffinityScorer struct {
+	activity map[int]ProjectActivity
+}
+
+// Score implements Scorer
+func (s preparedAffinityScorer) Score(task Task, user User) (float64, string) {
+	activity := s.activity[user.ID]
+	tasks := activity.Tasks()
+
+	if tasks == 0 {
+		return 0.0, fmt.Sprintf("Project %d: new", task.ProjectID)
+	}
+
+	score := float64(tasks) / float64(tasks+affinityHalfTasks)
+	return score, fmt.Sprintf(
+		"Project %d: %d open, %d recently completed",
+		task.ProjectID, activity.OpenTasks, activity.CompletedTasks,
+	)
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockProjectActivityRepository is a mock implementation of ProjectActivityRepository
+type MockProjectActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectActivityRepository) GetProjectActivity(
+	ctx context.Context,
+	projectID int,
+	userIDs []int,
+	since time.Time,
+) (map[int]ProjectActivity, error) {
+	args := m.Called(ctx, projectID, userIDs, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]ProjectActivity), args.Error(1)
+}
+
+func TestAffinityScorer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 11, 24, 9, 0, 0, 0, time.UTC)
+
+	repo := new(MockProjectActivityRepository)
+	repo.On("GetProjectActivity", ctx, 7, []int{1, 2}, now.AddDate(0, 0, -90)).Return(map[int]ProjectActivity{
+		1: {OpenTasks: 1, CompletedTasks: 1},
+	}, nil)
+
+	scorer := NewAffinityScorer(repo, 90*24*time.Hour)
+	scorer.now = func() time.Time { return now }
+
+	users := []User{{ID: 1}, {ID: 2}}
+	task := Task{ProjectID: 7}
+
+	prepared, err := scorer.Prepare(ctx, task, users)
+	require.NoError(t, err)
+
+	score, explanation := prepared.Score(task, users[0])
+	assert.Equal(t, 0.5, score)
+	assert.Equal(t, "Project 7: 1 open, 1 recently completed", explanation)
+
+	score, explanation = prepared.Score(task, users[1])
+	assert.Equal(t, 0.0, score)
+	assert.Equal(t, "Project 7: new", explanation)
+
+	repo.AssertExpectations(t)
+}
+
+func TestAffinityScorerWithoutProject(t *testing.T) {
+	repo := new(MockProjectActivityRepository)
+	scorer := NewAffinityScorer(repo, time.Hour)
+
+	prepared, err := scorer.Prepare(context.Background(), Task{}, []User{{ID: 1}})
+	require.NoError(t, err)
+
+	score, explanation := prepared.Score(Task{}, User{ID: 1})
+	assert.Equal(t, 0.0, score)
+	assert.Empty(t, explanation)
+	repo.AssertNotCalled(t, "GetProjectActivity")
+}
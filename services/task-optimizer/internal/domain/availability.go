@@ -0,0 +1,358 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AvailabilityMode defines what happens to users who are absent during a task
+type AvailabilityMode string
+
+const (
+	// AvailabilityModeOff ignores absences
+	AvailabilityModeOff AvailabilityMode = "off"
+
+	// AvailabilityModeExclude removes users available for less than the minimum share of the task window
+	AvailabilityModeExclude AvailabilityMode = "exclude"
+
+	// AvailabilityModeDownweight multiplies the total score by the available share of the task window
+	AvailabilityModeDownweight AvailabilityMode = "downweight"
+)
+
+// ParseAvailabilityMode validates an availability mode name
+func ParseAvailabilityMode(value string) (AvailabilityMode, error) {
+	switch mode := AvailabilityMode(value); mode {
+	case AvailabilityModeOff, AvailabilityModeExclude, AvailabilityModeDownweight:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown availability mode: %q", value)
+	}
+}
+
+// Absence is a period a user does not work, both dates inclusive
+type Absence struct {
+	Kind  string
+	Start time.Time
+	End   time.Time
+}
+
+// Covers reports whether the absence includes the given day
+func (a Absence) Covers(day time.Time) bool {
+	day = truncateDay(day)
+	return !day.Before(truncateDay(a.Start)) && !day.After(truncateDay(a.End))
+}
+
+// String returns "vacation until 2025-12-05"
+func (a Absence) String() string {
+	return fmt.Sprintf("%s until %s", a.Kind, a.End.Format(DateLayout))
+}
+
+// Availability is the working schedule of a user and their absences
+type Availability struct {
+	// WorkingDays lists the weekdays the user works, empty means Monday to Friday
+	WorkingDays []time.Weekday
+	Absences    []Absence
+}
+
+// WorksOn reports whether the day is a regular working day of the user
+func (a Availability) WorksOn(day time.Time) bool {
+	if len(a.WorkingDays) == 0 {
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	}
+
+	for _, weekday := range a.WorkingDays {
+		if day.Weekday() == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// AbsenceOn returns the absence covering the day, if any
+func (a Availability) AbsenceOn(day time.Time) (Absence, bool) {
+	for _, absence := range a.Absences {
+		if absence.Covers(day) {
+			return absence, true
+		}
+	}
+	return Absence{}, false
+}
+
+// AvailabilityPolicy checks whether users are around during the expected time window of a task.
+// The window starts today and ends on the due date, or after the working days the task needs
+// at the daily hours of the capacity policy when the task has no due date.
+type AvailabilityPolicy struct {
+	repo     AvailabilityRepository
+	mode     AvailabilityMode
+	capacity CapacityPolicy
+	minShare float64
+	now      func() time.Time
+}
+
+// NewAvailabilityPolicy creates an availability policy.
+// In exclude mode users available for less than minShare of the window's working days are excluded.
+func NewAvailabilityPolicy(
+	repo AvailabilityRepository,
+	mode AvailabilityMode,
+	capacity CapacityPolicy,
+	minShare float64,
+) *AvailabilityPolicy {
+	return &AvailabilityPolicy{
+		repo:     repo,
+		mode:     mode,
+		capacity: capacity,
+		minShare: minShare,
+		now:      time.Now,
+	}
+}
+
+// Check loads the availability of users for the task window.
+// A nil policy or the off mode returns a nil check, which treats everyone as available.
+func (p *AvailabilityPolicy) Check(ctx context.Context, task Task, users []User) (*AvailabilityCheck, error) {
+	if p == nil || p.mode == AvailabilityModeOff || len(users) == 0 {
+		return nil, nil
+	}
+
+	from, to := p.window(task)
+
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	availability, err := p.repo.GetAvailability(ctx, userIDs, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+
+	check := &AvailabilityCheck{
+		mode:     p.mode,
+		minShare: p.minShare,
+		shares:   make(map[int]float64, len(users)),
+		absences: make(map[int]Absence),
+	}
+
+	for _, user := range users {
+		share, absence, absent := availableShare(availability[user.ID], from, to)
+		check.shares[user.ID] = share
+		if absent {
+			check.absences[user.ID] = absence
+		}
+	}
+
+	return check, nil
+}
+
+// window returns the first and last day the task is expected to be worked on
+func (p *AvailabilityPolicy) window(task Task) (time.Time, time.Time) {
+	from := truncateDay(p.now())
+
+	if task.DueDate != nil && !truncateDay(*task.DueDate).Before(from) {
+		return from, truncateDay(*task.DueDate)
+	}
+
+	dailyHours := p.capacity.WeeklyHours / workingDaysPerWeek
+	if dailyHours <= 0 {
+		dailyHours = 8
+	}
+
+	days := ceilDiv(p.capacity.TaskHours(task), dailyHours)
+	if days < 1 {
+		days = 1
+	}
+
+	return from, addWorkingDays(from, days-1)
+}
+
+// AvailabilityCheck is the availability of the candidates for one task
+type AvailabilityCheck struct {
+	mode     AvailabilityMode
+	minShare float64
+	shares   map[int]float64
+	absences map[int]Absence
+}
+
+// Share returns the share of the task window the user is available, from 0 to 1
+func (c *AvailabilityCheck) Share(userID int) float64 {
+	if c == nil {
+		return 1.0
+	}
+	if share, ok := c.shares[userID]; ok {
+		return share
+	}
+	return 1.0
+}
+
+// Absence returns the first absence of the user within the task window, if any
+func (c *AvailabilityCheck) Absence(userID int) (Absence, bool) {
+	if c == nil {
+		return Absence{}, false
+	}
+	absence, ok := c.absences[userID]
+	return absence, ok
+}
+
+// Excluded returns why the user cannot take the task in exclude mode, or nil
+func (c *AvailabilityCheck) Excluded(user User) error {
+	if c == nil || c.mode != AvailabilityModeExclude {
+		return nil
+	}
+
+	share := c.Share(user.ID)
+	if share >= c.minShare {
+		return nil
+	}
+
+	if absence, ok := c.Absence(user.ID); ok {
+		return fmt.Errorf("absent: %s, available %.0f%% of task window", absence, share*100)
+	}
+	return fmt.Errorf("not working during task window")
+}
+
+// Weight returns the multiplier of the user's total score
+func (c *AvailabilityCheck) Weight(userID int) float64 {
+	if c == nil || c.mode != AvailabilityModeDownweight {
+		return 1.0
+	}
+	return c.Share(userID)
+}
+
+// filter removes users excluded by the check
+func (c *AvailabilityCheck) filter(users []User) ([]User, []Exclusion) {
+	if c == nil || c.mode != AvailabilityModeExclude {
+		return users, nil
+	}
+
+	available := make([]User, 0, len(users))
+	var exclusions []Exclusion
+
+	for _, user := range users {
+		if err := c.Excluded(user); err != nil {
+			exclusions = append(exclusions, Exclusion{
+				UserID:   user.ID,
+				UserName: user.Name,
+				Reason:   err.Error(),
+			})
+			continue
+		}
+		available = append(available, user)
+	}
+
+	return available, exclusions
+}
+
+// apply down-weights scores of partly absent users and notes absences in their reasons.
+// It returns the scores before down-weighting keyed by user ID.
+func (c *AvailabilityCheck) apply(results []AssignmentResult) map[int]float64 {
+	if c == nil || c.mode != AvailabilityModeDownweight {
+		return nil
+	}
+
+	undiscounted := make(map[int]float64, len(results))
+	for i := range results {
+		result := &results[i]
+		undiscounted[result.UserID] = result.TotalScore
+
+		absence, ok := c.Absence(result.UserID)
+		if !ok {
+			continue
+		}
+
+		share := c.Share(result.UserID)
+		result.TotalScore *= share
+		result.Reason = appendReason(result.Reason, fmt.Sprintf(
+			"Availability: %.0f%% (%s)", share*100, absence,
+		))
+	}
+
+	return undiscounted
+}
+
+// skippedReason lists absent users that would have outranked the winner otherwise
+func (c *AvailabilityCheck) skippedReason(
+	winner AssignmentResult,
+	excluded []Exclusion,
+	ranked []AssignmentResult,
+	undiscounted map[int]float64,
+) string {
+	if c == nil {
+		return ""
+	}
+
+	var names []string
+	for _, exclusion := range excluded {
+		if absence, ok := c.Absence(exclusion.UserID); ok {
+			names = append(names, fmt.Sprintf("%s (%s)", exclusion.UserName, absence))
+		}
+	}
+
+	for _, result := range ranked {
+		if result.UserID == winner.UserID {
+			continue
+		}
+		absence, ok := c.Absence(result.UserID)
+		if ok && undiscounted[result.UserID] > undiscounted[winner.UserID] {
+			names = append(names, fmt.Sprintf("%s (%s)", result.UserName, absence))
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+	return "Skipped as absent: " + strings.Join(names, ", ")
+}
+
+// availableShare returns the share of the user's working days in the window they are not absent,
+// and the first absence within the window
+func availableShare(availability Availability, from, to time.Time) (float64, Absence, bool) {
+	working := 0
+	available := 0
+	var first Absence
+	absent := false
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if !availability.WorksOn(day) {
+			continue
+		}
+		working++
+
+		absence, ok := availability.AbsenceOn(day)
+		if !ok {
+			available++
+			continue
+		}
+		if !absent {
+			first, absent = absence, true
+		}
+	}
+
+	if working == 0 {
+		return 0.0, first, absent
+	}
+
+	return float64(available) / float64(working), first, absent
+}
+
+// addWorkingDays moves forward by the given number of weekdays
+func addWorkingDays(day time.Time, days int) time.Time {
+	for days > 0 {
+		day = day.AddDate(0, 0, 1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return day
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func appendReason(reason, extra string) string {
+	if reason == "" {
+		return extra
+	}
+	return reason + ", " + extra
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAvailabilityRepository is a mock implementation of AvailabilityRepository
+type MockAvailabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityRepository) GetAvailability(
+	ctx context.Context,
+	userIDs []int,
+	from, to time.Time,
+) (map[int]Availability, error) {
+	args := m.Called(ctx, userIDs, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]Availability), args.Error(1)
+}
+
+func TestAvailableShare(t *testing.T) {
+	monday := time.Date(2025, 11, 24, 0, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+	vacation := Absence{Kind: "vacation", Start: monday, End: monday.AddDate(0, 0, 1)}
+
+	t.Run("no absences", func(t *testing.T) {
+		share, _, absent := availableShare(Availability{}, monday, friday)
+		assert.Equal(t, 1.0, share)
+		assert.False(t, absent)
+	})
+
+	t.Run("two of five days absent", func(t *testing.T) {
+		share, absence, absent := availableShare(Availability{Absences: []Absence{vacation}}, monday, friday)
+		assert.InDelta(t, 0.6, share, 1e-9)
+		assert.True(t, absent)
+		assert.Equal(t, "vacation until 2025-11-25", absence.String())
+	})
+
+	t.Run("part-time schedule", func(t *testing.T) {
+		partTime := Availability{
+			WorkingDays: []time.Weekday{time.Monday, time.Wednesday},
+			Absences:    []Absence{vacation},
+		}
+		share, _, _ := availableShare(partTime, monday, friday)
+		assert.InDelta(t, 0.5, share, 1e-9)
+	})
+
+	t.Run("not working in window", func(t *testing.T) {
+		weekendOnly := Availability{WorkingDays: []time.Weekday{time.Saturday}}
+		share, _, absent := availableShare(weekendOnly, monday, friday)
+		assert.Equal(t, 0.0, share)
+		assert.False(t, absent)
+	})
+}
+
+func TestRankCandidatesWithAvailability(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2025, 11, 24, 9, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+
+	users := []User{
+		{ID: 1, Name: "Alice", Skills: SkillsFromNames("go"), CurrentLoad: 0, MaxCapacity: 10},
+		{ID: 2, Name: "Bob", Skills: SkillsFromNames("go"), CurrentLoad: 5, MaxCapacity: 10},
+	}
+	task := Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("go"), DueDate: &friday}
+
+	// Alice is on vacation Monday to Thursday
+	availability := map[int]Availability{
+		1: {Absences: []Absence{{Kind: "vacation", Start: monday, End: monday.AddDate(0, 0, 3)}}},
+	}
+
+	newService := func(mode AvailabilityMode) (*OptimizerService, *MockAvailabilityRepository) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		availabilityRepo := new(MockAvailabilityRepository)
+		policy := NewAvailabilityPolicy(availabilityRepo, mode, DefaultCapacityPolicy(), 0.5)
+		policy.now = func() time.Time { return monday }
+
+		return NewOptimizerService(userRepo, WithAvailability(policy)), availabilityRepo
+	}
+
+	t.Run("exclude", func(t *testing.T) {
+		service, availabilityRepo := newService(AvailabilityModeExclude)
+		availabilityRepo.On("GetAvailability", ctx, []int{1, 2}, truncateDay(monday), truncateDay(friday)).
+			Return(availability, nil)
+
+		candidates, err := service.RankCandidates(ctx, task, 0)
+
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, 2, candidates[0].UserID)
+		assert.Contains(t, candidates[0].Reason, "Skipped as absent: Alice (vacation until 2025-11-27)")
+	})
+
+	t.Run("downweight", func(t *testing.T) {
+		service, availabilityRepo := newService(AvailabilityModeDownweight)
+		availabilityRepo.On("GetAvailability", ctx, []int{1, 2}, mock.Anything, mock.Anything).
+			Return(availability, nil)
+
+		candidates, err := service.RankCandidates(ctx, task, 0)
+
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+		assert.Equal(t, 2, candidates[0].UserID)
+		assert.Contains(t, candidates[0].Reason, "Skipped as absent: Alice")
+		assert.Contains(t, candidates[1].Reason, "Availability: 20% (vacation until 2025-11-27)")
+	})
+
+	t.Run("everyone absent", func(t *testing.T) {
+		service, availabilityRepo := newService(AvailabilityModeExclude)
+		availabilityRepo.On("GetAvailability", ctx, []int{1, 2}, mock.Anything, mock.Anything).
+			Return(map[int]Availability{
+				1: availability[1],
+				2: availability[1],
+			}, nil)
+
+		_, err := service.RankCandidates(ctx, task, 0)
+
+		assert.True(t, errors.Is(err, ErrNoSuitableUsers))
+	})
+}
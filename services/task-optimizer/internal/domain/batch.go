@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TaskAssignment pairs a task with the user chosen for it
+type TaskAssignment struct {
+	Task   Task
+	Result AssignmentResult
+
+	// Alternatives are the best results of the other users that could take the task
+	// in the round it was matched, ordered from best to worst score
+	Alternatives []AssignmentResult
+}
+
+// preparedTask holds the per-user data a task is scored with. It depends on the task
+// and the user IDs only, so it is loaded once per batch and reused in every round.
+type preparedTask struct {
+	scorer       *CompositeScorer
+	availability *AvailabilityCheck
+}
+
+// BatchAssignment is the jointly optimal assignment of a batch of tasks
+type BatchAssignment struct {
+	Assignments []TaskAssignment
+	Unassigned  []Task
+	TotalScore  float64
+}
+
+// BatchAssign assigns several tasks at once, maximizing the total score of the batch.
+// The task×slot matching is solved with the Hungarian algorithm, so a burst of tasks
+// is spread over users instead of all landing on the same top scorer.
+// Tasks that fit nobody's remaining capacity or constraints are returned as unassigned.
+func (s *OptimizerService) BatchAssign(ctx context.Context, tasks []Task) (*BatchAssignment, error) {
+	if len(tasks) == 0 {
+		return &BatchAssignment{}, nil
+	}
+
+	activeUsers, err := s.userRepo.GetActiveUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	if len(activeUsers) == 0 {
+		return nil, ErrNoSuitableUsers
+	}
+
+	// Loads are booked on a copy so the repository's slice is left untouched
+	users := append([]User(nil), activeUsers...)
+
+	prepared, err := s.prepareBatch(ctx, tasks, users)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]int, len(tasks))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	assigned := map[int]TaskAssignment{}
+	var unassigned []int
+
+	// Slots are charged the average task cost, so a round may overbook a user with
+	// larger tasks. Those tasks are matched again against the loads actually booked.
+	for len(pending) > 0 {
+		matched, infeasible := s.matchBatch(tasks, prepared, pending, users)
+
+		unassigned = append(unassigned, infeasible...)
+		if len(matched) == 0 {
+			break
+		}
+
+		pending = s.bookMatched(tasks, matched, users, assigned)
+	}
+
+	batch := &BatchAssignment{}
+	for i := range tasks {
+		if assignment, ok := assigned[i]; ok {
+			batch.Assignments = append(batch.Assignments, assignment)
+			batch.TotalScore += assignment.Result.TotalScore
+		}
+	}
+
+	sort.Ints(unassigned)
+	for _, i := range unassigned {
+		batch.Unassigned = append(batch.Unassigned, tasks[i])
+	}
+
+	return batch, nil
+}
+
+// prepareBatch loads the scoring data and availability of every task for the users
+func (s *OptimizerService) prepareBatch(ctx context.Context, tasks []Task, users []User) ([]preparedTask, error) {
+	prepared := make([]preparedTask, len(tasks))
+
+	for i, task := range tasks {
+		scorer, err := s.scorer.Prepare(ctx, task, users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare scoring: %w", err)
+		}
+
+		availability, err := s.availability.Check(ctx, task, users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+
+		prepared[i] = preparedTask{scorer: scorer, availability: availability}
+	}
+
+	return prepared, nil
+}
+
+// matchBatch solves one round of the task×slot matching for the pending tasks.
+// It returns the chosen assignment by task index and the tasks that fit no slot.
+func (s *OptimizerService) matchBatch(tasks []Task, prepared []preparedTask, pending []int, users []User) (map[int]TaskAssignment, []int) {
+	round := make([]Task, len(pending))
+	for i, index := range pending {
+		round[i] = tasks[index]
+	}
+
+	slots := s.buildSlots(round, users)
+
+	// One dummy column per task lets the solver leave a task unassigned at zero cost
+	columns := len(slots) + len(round)
+	cost := make([][]float64, len(round))
+	results := make([][]AssignmentResult, len(round))
+
+	for i, task := range round {
+		cost[i] = make([]float64, columns)
+		results[i] = make([]AssignmentResult, len(slots))
+		scorer, availability := prepared[pending[i]].scorer, prepared[pending[i]].availability
+
+		for j, slot := range slots {
+			if !s.capacity.Fits(slot, task) ||
+				checkConstraints(s.constraints, task, slot) != nil ||
+				availability.Excluded(slot) != nil {
+				cost[i][j] = infeasibleCost
+				continue
+			}
+
+			results[i][j] = score(scorer, task, slot)
+			results[i][j].TotalScore *= availability.Weight(slot.ID)
+			cost[i][j] = -results[i][j].TotalScore
+		}
+	}
+
+	matched := map[int]TaskAssignment{}
+	var infeasible []int
+	for i, column := range solveAssignment(cost) {
+		if column >= len(slots) || cost[i][column] >= infeasibleCost {
+			infeasible = append(infeasible, pending[i])
+			continue
+		}
+
+		matched[pending[i]] = TaskAssignment{
+			Task:         round[i],
+			Result:       results[i][column],
+			Alternatives: runnerUps(results[i], cost[i], results[i][column].UserID),
+		}
+	}
+
+	return matched, infeasible
+}
+
+// runnerUps returns the best feasible slot of every user other than the assignee,
+// ordered from best to worst score
+func runnerUps(results []AssignmentResult, cost []float64, assigneeID int) []AssignmentResult {
+	best := map[int]int{}
+	var order []int
+
+	for j, result := range results {
+		if cost[j] >= infeasibleCost || result.UserID == assigneeID {
+			continue
+		}
+
+		current, seen := best[result.UserID]
+		switch {
+		case !seen:
+			best[result.UserID] = j
+			order = append(order, result.UserID)
+		case result.TotalScore > results[current].TotalScore:
+			best[result.UserID] = j
+		}
+	}
+
+	alternatives := make([]AssignmentResult, 0, len(order))
+	for _, userID := range order {
+		alternatives = append(alternatives, results[best[userID]])
+	}
+
+	// Stable sort keeps user order between equal scores, like single assignments
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return alternatives[i].TotalScore > alternatives[j].TotalScore
+	})
+
+	return alternatives
+}
+
+// bookMatched books the matched tasks on their users by real task cost, largest first,
+// so small tasks are the ones left over and can still fit in another user's gaps.
+// It records the kept results in assigned, updates the users' loads and returns
+// the tasks that overflowed their user's capacity.
+func (s *OptimizerService) bookMatched(tasks []Task, matched map[int]TaskAssignment, users []User, assigned map[int]TaskAssignment) []int {
+	byUser := map[int][]int{}
+	for index, assignment := range matched {
+		byUser[assignment.Result.UserID] = append(byUser[assignment.Result.UserID], index)
+	}
+
+	var overflow []int
+	for u := range users {
+		indexes := byUser[users[u].ID]
+		sort.Slice(indexes, func(a, b int) bool {
+			costA, costB := s.capacity.TaskCost(tasks[indexes[a]]), s.capacity.TaskCost(tasks[indexes[b]])
+			if costA != costB {
+				return costA > costB
+			}
+			return indexes[a] < indexes[b]
+		})
+
+		for _, index := range indexes {
+			task := tasks[index]
+			if !s.capacity.Fits(users[u], task) {
+				overflow = append(overflow, index)
+				continue
+			}
+
+			users[u].CurrentLoad += s.capacity.TaskCost(task)
+			users[u].QueuedHours += s.capacity.TaskHours(task)
+			assigned[index] = matched[index]
+		}
+	}
+
+	sort.Ints(overflow)
+	return overflow
+}
+
+// buildSlots expands every user into as many slots as tasks they could still take.
+// Slot k of a user is scored as if k earlier tasks of the batch were already booked.
+func (s *OptimizerService) buildSlots(tasks []Task, users []User) []User {
+	unit := s.averageTaskCost(tasks)
+	hours := s.averageTaskHours(tasks)
+	slots := make([]User, 0, len(users))
+
+	for _, user := range users {
+		for k := 0; k < len(tasks); k++ {
+			booked := user
+			booked.CurrentLoad += k * unit
+			booked.QueuedHours += k * hours
+
+			if booked.CurrentLoad >= booked.MaxCapacity {
+				break
+			}
+
+			slots = append(slots, booked)
+		}
+	}
+
+	return slots
+}
+
+func (s *OptimizerService) averageTaskCost(tasks []Task) int {
+	total := 0
+	for _, task := range tasks {
+		total += s.capacity.TaskCost(task)
+	}
+	return ceilDiv(total, len(tasks))
+}
+
+func (s *OptimizerService) averageTaskHours(tasks []Task) int {
+	total := 0
+	for _, task := range tasks {
+		total += s.capacity.TaskHours(task)
+	}
+	return ceilDiv(total, len(tasks))
+}
+
+func ceilDiv(a, b int) int {
+	if b == 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveAssignment(t *testing.T) {
+	t.Run("square matrix", func(t *testing.T) {
+		cost := [][]float64{
+			{4, 1, 3},
+			{2, 0, 5},
+			{3, 2, 2},
+		}
+
+		assignment := solveAssignment(cost)
+
+		assert.Equal(t, []int{1, 0, 2}, assignment)
+	})
+
+	t.Run("matches brute force on random rectangular matrices", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(42))
+
+		for iteration := 0; iteration < 50; iteration++ {
+			rows := 1 + rng.Intn(4)
+			cols := rows + rng.Intn(3)
+
+			cost := make([][]float64, rows)
+			for i := range cost {
+				cost[i] = make([]float64, cols)
+				for j := range cost[i] {
+					cost[i][j] = math.Round(rng.Float64()*100) / 10
+				}
+			}
+
+			assignment := solveAssignment(cost)
+
+			assert.InDelta(t, bruteForceAssignment(cost), assignmentCost(cost, assignment), 1e-9)
+			assert.Len(t, uniqueColumns(assignment), rows, "columns must not repeat")
+		}
+	})
+
+	t.Run("empty matrix", func(t *testing.T) {
+		assert.Nil(t, solveAssignment(nil))
+	})
+}
+
+func TestBatchAssign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("spreads tasks instead of piling on the top scorer", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+
+		users := []User{
+			{ID: 1, Name: "Expert", Skills: SkillsFromNames("go", "php"), CurrentLoad: 5, MaxCapacity: 10},
+			{ID: 2, Name: "Backend", Skills: SkillsFromNames("php"), CurrentLoad: 2, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		tasks := []Task{
+			{ID: 10, Priority: 3, Skills: RequirementsFromNames("php")},
+			{ID: 11, Priority: 3, Skills: RequirementsFromNames("go", "php")},
+		}
+
+		batch, err := service.BatchAssign(ctx, tasks)
+
+		require.NoError(t, err)
+		require.Len(t, batch.Assignments, 2)
+		assert.Empty(t, batch.Unassigned)
+
+		assignees := map[int]int{}
+		for _, a := range batch.Assignments {
+			assignees[a.Task.ID] = a.Result.UserID
+		}
+		assert.Equal(t, map[int]int{10: 2, 11: 1}, assignees, "go task goes to the only go developer")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("maximizes total score over greedy choice", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		weights := []FactorWeight{{Name: FactorSkill, Weight: 1}}
+		scorer, err := NewCompositeScorerFromWeights(weights)
+		require.NoError(t, err)
+		service := NewOptimizerService(mockRepo, WithScorer(scorer))
+
+		users := []User{
+			{ID: 1, Name: "Fullstack", Skills: SkillsFromNames("php", "vue"), CurrentLoad: 0, MaxCapacity: 1},
+			{ID: 2, Name: "Backend", Skills: SkillsFromNames("php"), CurrentLoad: 0, MaxCapacity: 1},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		// Greedy in order would give the php task to Fullstack and leave vue to Backend
+		tasks := []Task{
+			{ID: 1, Skills: RequirementsFromNames("php")},
+			{ID: 2, Skills: RequirementsFromNames("php", "vue")},
+		}
+
+		batch, err := service.BatchAssign(ctx, tasks)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 2.0, batch.TotalScore, 1e-9)
+	})
+
+	t.Run("respects capacity and reports leftovers", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+
+		users := []User{
+			{ID: 1, Name: "Almost full", Skills: SkillsFromNames("php"), CurrentLoad: 9, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		tasks := []Task{
+			{ID: 1, Priority: 5, Skills: RequirementsFromNames("php")},
+			{ID: 2, Priority: 1, Skills: RequirementsFromNames("php")},
+		}
+
+		batch, err := service.BatchAssign(ctx, tasks)
+
+		require.NoError(t, err)
+		require.Len(t, batch.Assignments, 1)
+		require.Len(t, batch.Unassigned, 1)
+		assert.Equal(t, 1, batch.Assignments[0].Task.ID, "higher priority task wins the last slot")
+		assert.Equal(t, 2, batch.Unassigned[0].ID)
+	})
+
+	t.Run("respects capacity with mixed task sizes", func(t *testing.T) {
+		capacity := DefaultCapacityPolicy()
+		capacity.Model = CapacityModelRemainingHours
+
+		tests := []struct {
+			name       string
+			users      []User
+			tasks      []Task
+			assigned   map[int]int
+			unassigned []int
+		}{
+			{
+				name: "large task overflows a single user",
+				users: []User{
+					{ID: 1, Name: "Solo", Skills: SkillsFromNames("php"), MaxCapacity: 40},
+				},
+				tasks: []Task{
+					{ID: 1, Priority: 3, EstimatedHours: 38, Skills: RequirementsFromNames("php")},
+					{ID: 2, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+					{ID: 3, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+				},
+				assigned:   map[int]int{1: 1, 2: 1},
+				unassigned: []int{3},
+			},
+			{
+				name: "overflowing task moves to another user",
+				users: []User{
+					{ID: 1, Name: "Free", Skills: SkillsFromNames("php"), MaxCapacity: 40},
+					{ID: 2, Name: "Busy", Skills: SkillsFromNames("php"), CurrentLoad: 37, MaxCapacity: 40},
+				},
+				tasks: []Task{
+					{ID: 1, Priority: 3, EstimatedHours: 38, Skills: RequirementsFromNames("php")},
+					{ID: 2, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+					{ID: 3, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+				},
+				assigned: map[int]int{1: 1, 2: 1, 3: 2},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockRepo := new(MockUserRepository)
+				service := NewOptimizerService(mockRepo,
+					WithCapacity(capacity),
+					WithConstraints(CapacityConstraint{Capacity: capacity}),
+				)
+				mockRepo.On("GetActiveUsers", ctx).Return(tt.users, nil)
+
+				batch, err := service.BatchAssign(ctx, tt.tasks)
+
+				require.NoError(t, err)
+
+				assignees := map[int]int{}
+				booked := map[int]int{}
+				for _, a := range batch.Assignments {
+					assignees[a.Task.ID] = a.Result.UserID
+					booked[a.Result.UserID] += a.Task.EstimatedHours
+				}
+				assert.Equal(t, tt.assigned, assignees)
+
+				var unassigned []int
+				for _, task := range batch.Unassigned {
+					unassigned = append(unassigned, task.ID)
+				}
+				assert.Equal(t, tt.unassigned, unassigned)
+
+				for _, user := range tt.users {
+					assert.LessOrEqual(t, user.CurrentLoad+booked[user.ID], user.MaxCapacity,
+						"user %d is overbooked", user.ID)
+				}
+			})
+		}
+	})
+
+	t.Run("lists the other users as alternatives", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+
+		users := []User{
+			{ID: 1, Name: "Busy", Skills: SkillsFromNames("php"), CurrentLoad: 6, MaxCapacity: 10},
+			{ID: 2, Name: "Free", Skills: SkillsFromNames("php"), CurrentLoad: 0, MaxCapacity: 10},
+			{ID: 3, Name: "Half", Skills: SkillsFromNames("php"), CurrentLoad: 3, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		tasks := []Task{
+			{ID: 1, Priority: 3, Skills: RequirementsFromNames("php")},
+			{ID: 2, Priority: 3, Skills: RequirementsFromNames("php")},
+		}
+
+		batch, err := service.BatchAssign(ctx, tasks)
+
+		require.NoError(t, err)
+		require.Len(t, batch.Assignments, 2)
+		for _, assignment := range batch.Assignments {
+			require.Len(t, assignment.Alternatives, 2, "one alternative per other user")
+
+			seen := map[int]bool{assignment.Result.UserID: true}
+			for i, alternative := range assignment.Alternatives {
+				assert.False(t, seen[alternative.UserID], "user %d listed twice", alternative.UserID)
+				seen[alternative.UserID] = true
+				if i > 0 {
+					assert.GreaterOrEqual(t, assignment.Alternatives[i-1].TotalScore, alternative.TotalScore)
+				}
+			}
+		}
+	})
+
+	t.Run("loads availability once per task across rounds", func(t *testing.T) {
+		capacity := DefaultCapacityPolicy()
+		capacity.Model = CapacityModelRemainingHours
+
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("GetActiveUsers", ctx).Return([]User{
+			{ID: 1, Name: "Solo", Skills: SkillsFromNames("php"), MaxCapacity: 40},
+		}, nil)
+
+		availabilityRepo := new(MockAvailabilityRepository)
+		availabilityRepo.On("GetAvailability", ctx, []int{1}, mock.Anything, mock.Anything).
+			Return(map[int]Availability{}, nil)
+		policy := NewAvailabilityPolicy(availabilityRepo, AvailabilityModeExclude, capacity, 0.5)
+		policy.now = func() time.Time { return time.Date(2025, 11, 24, 0, 0, 0, 0, time.UTC) }
+
+		service := NewOptimizerService(mockRepo, WithCapacity(capacity), WithAvailability(policy))
+
+		// The large task overflows the first round, so the small ones are matched again
+		tasks := []Task{
+			{ID: 1, Priority: 3, EstimatedHours: 38, Skills: RequirementsFromNames("php")},
+			{ID: 2, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+			{ID: 3, Priority: 3, EstimatedHours: 2, Skills: RequirementsFromNames("php")},
+		}
+
+		batch, err := service.BatchAssign(ctx, tasks)
+
+		require.NoError(t, err)
+		assert.Len(t, batch.Unassigned, 1)
+		availabilityRepo.AssertNumberOfCalls(t, "GetAvailability", len(tasks))
+	})
+
+	t.Run("returns error when no users available", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+		mockRepo.On("GetActiveUsers", ctx).Return([]User{}, nil)
+
+		_, err := service.BatchAssign(ctx, []Task{{ID: 1}})
+
+		assert.ErrorIs(t, err, ErrNoSuitableUsers)
+	})
+}
+
+func bruteForceAssignment(cost [][]float64) float64 {
+	best := math.Inf(1)
+	used := make([]bool, len(cost[0]))
+
+	var search func(row int, total float64)
+	search = func(row int, total float64) {
+		if row == len(cost) {
+			best = math.Min(best, total)
+			return
+		}
+		for j := range cost[row] {
+			if used[j] {
+				continue
+			}
+			used[j] = true
+			search(row+1, total+cost[row][j])
+			used[j] = false
+		}
+	}
+	search(0, 0)
+
+	return best
+}
+
+func assignmentCost(cost [][]float64, assignment []int) float64 {
+	total := 0.0
+	for i, j := range assignment {
+		total += cost[i][j]
+	}
+	return total
+}
+
+func uniqueColumns(assignment []int) map[int]bool {
+	columns := map[int]bool{}
+	for _, j := range assignment {
+		columns[j] = true
+	}
+	return columns
+}
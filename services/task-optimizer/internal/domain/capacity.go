@@ -0,0 +1,114 @@
+package domain
+
+import "fmt"
+
+// CapacityModel defines the unit in which user load and capacity are measured
+type CapacityModel string
+
+const (
+	// CapacityModelTasks measures load as the number of open tasks
+	CapacityModelTasks CapacityModel = "tasks"
+
+	// CapacityModelHours measures load as the estimated hours booked on open tasks,
+	// regardless of the hours already spent on them
+	CapacityModelHours CapacityModel = "hours"
+
+	// CapacityModelRemainingHours measures load as the remaining estimated hours
+	// of open tasks against a weekly hour capacity
+	CapacityModelRemainingHours CapacityModel = "remaining_hours"
+)
+
+// DefaultMaxWorkload is the max_workload of a full-time user in Laravel.
+// max_workload is a percentage of a full-time user in every capacity model: Laravel
+// accepts 1 to 200, and capacities are the full-time values scaled by max_workload / 100.
+const DefaultMaxWorkload = 100
+
+// CapacityPolicy describes how user load and capacity are derived
+type CapacityPolicy struct {
+	Model CapacityModel
+
+	// TasksPerUser is the open task limit of a full-time user in the tasks model.
+	// Users with a reduced max_workload get a proportionally smaller limit.
+	TasksPerUser int
+
+	// WeeklyHours is the capacity of a full-time user in the hours and remaining hours models
+	WeeklyHours int
+
+	// DefaultTaskHours is assumed for tasks without an estimate
+	DefaultTaskHours int
+}
+
+// DefaultCapacityPolicy returns the task count model with 10 tasks per full-time user
+func DefaultCapacityPolicy() CapacityPolicy {
+	return CapacityPolicy{
+		Model:            CapacityModelTasks,
+		TasksPerUser:     10,
+		WeeklyHours:      40,
+		DefaultTaskHours: 4,
+	}
+}
+
+// ParseCapacityModel validates a capacity model name
+func ParseCapacityModel(value string) (CapacityModel, error) {
+	switch model := CapacityModel(value); model {
+	case CapacityModelTasks, CapacityModelHours, CapacityModelRemainingHours:
+		return model, nil
+	default:
+		return "", fmt.Errorf("unknown capacity model: %q", value)
+	}
+}
+
+// TaskCapacity scales the full-time task limit by the user's max workload
+func (p CapacityPolicy) TaskCapacity(maxWorkload int) int {
+	return scaleByWorkload(p.TasksPerUser, maxWorkload)
+}
+
+// HourCapacity scales the full-time weekly hours by the user's max workload
+func (p CapacityPolicy) HourCapacity(maxWorkload int) int {
+	return scaleByWorkload(p.WeeklyHours, maxWorkload)
+}
+
+// TaskHours returns the estimated size of a task in hours
+func (p CapacityPolicy) TaskHours(task Task) int {
+	if task.EstimatedHours > 0 {
+		return task.EstimatedHours
+	}
+	return p.DefaultTaskHours
+}
+
+// TaskCost returns how much capacity a task consumes in the model's unit
+func (p CapacityPolicy) TaskCost(task Task) int {
+	switch p.Model {
+	case CapacityModelHours, CapacityModelRemainingHours:
+		return p.TaskHours(task)
+	default:
+		return 1
+	}
+}
+
+// Fits reports whether the task fits into the user's remaining capacity
+func (p CapacityPolicy) Fits(user User, task Task) bool {
+	return user.CurrentLoad+p.TaskCost(task) <= user.MaxCapacity
+}
+
+// Unit returns the short unit suffix used in explanations
+func (p CapacityPolicy) Unit() string {
+	switch p.Model {
+	case CapacityModelHours, CapacityModelRemainingHours:
+		return "h"
+	default:
+		return ""
+	}
+}
+
+func scaleByWorkload(fullTime, maxWorkload int) int {
+	if maxWorkload <= 0 || fullTime <= 0 {
+		return 0
+	}
+
+	capacity := (fullTime*maxWorkload + DefaultMaxWorkload/2) / DefaultMaxWorkload
+	if capacity < 1 {
+		return 1
+	}
+	return capacity
+}
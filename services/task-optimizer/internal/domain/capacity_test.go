@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapacityPolicyTaskCapacity(t *testing.T) {
+	policy := CapacityPolicy{Model: CapacityModelTasks, TasksPerUser: 10}
+
+	tests := []struct {
+		name        string
+		maxWorkload int
+		expected    int
+	}{
+		{"full time", 100, 10},
+		{"part time", 50, 5},
+		{"lead with reduced capacity", 30, 3},
+		{"overtime", 120, 12},
+		{"rounds to nearest", 45, 5},
+		{"tiny workload keeps one slot", 1, 1},
+		{"no workload", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.TaskCapacity(tt.maxWorkload))
+		})
+	}
+}
+
+func TestParseCapacityModel(t *testing.T) {
+	model, err := ParseCapacityModel("hours")
+	assert.NoError(t, err)
+	assert.Equal(t, CapacityModelHours, model)
+
+	_, err = ParseCapacityModel("story_points")
+	assert.Error(t, err)
+}
+
+func TestLoadScorerChecksIncomingTaskFits(t *testing.T) {
+	scorer := LoadScorer{Capacity: CapacityPolicy{
+		Model:            CapacityModelRemainingHours,
+		WeeklyHours:      40,
+		DefaultTaskHours: 4,
+	}}
+
+	user := User{CurrentLoad: 30, MaxCapacity: 40}
+
+	tests := []struct {
+		name     string
+		estimate int
+		expected float64
+	}{
+		{"small task fits", 8, 0.25},
+		{"task fills capacity exactly", 10, 0.25},
+		{"epic does not fit", 40, 0.0},
+		{"unestimated task uses default size", 0, 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, explanation := scorer.Score(Task{EstimatedHours: tt.estimate}, user)
+			assert.InDelta(t, tt.expected, score, 1e-9)
+			assert.Equal(t, "Load: 30/40h", explanation)
+		})
+	}
+}
+
+func TestCapacityPolicyTaskCost(t *testing.T) {
+	task := Task{EstimatedHours: 12}
+
+	assert.Equal(t, 1, CapacityPolicy{Model: CapacityModelTasks}.TaskCost(task))
+	assert.Equal(t, 12, CapacityPolicy{Model: CapacityModelRemainingHours}.TaskCost(task))
+	assert.Equal(t, 3, CapacityPolicy{Model: CapacityModelHours, DefaultTaskHours: 3}.TaskCost(Task{}))
+}
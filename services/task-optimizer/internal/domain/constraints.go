@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Built-in constraint names
+const (
+	ConstraintRequiredSkills = "required_skills"
+	ConstraintCapacity       = "capacity"
+	ConstraintBlocklist      = "blocklist"
+)
+
+// Constraint decides whether a user may be assigned a task at all.
+// Constraints run before scoring, so ineligible users never win on other factors.
+type Constraint interface {
+	// Check returns an error describing why the user is not eligible, or nil
+	Check(task Task, user User) error
+}
+
+// Exclusion records why a user was removed from the candidate list
+type Exclusion struct {
+	UserID   int
+	UserName string
+	Reason   string
+}
+
+// NoSuitableUsersError is returned when constraints exclude every user.
+// It matches ErrNoSuitableUsers with errors.Is.
+type NoSuitableUsersError struct {
+	Exclusions []Exclusion
+}
+
+// Error implements error
+func (e *NoSuitableUsersError) Error() string {
+	reasons := make([]string, 0, len(e.Exclusions))
+	for _, ex := range e.Exclusions {
+		reasons = append(reasons, fmt.Sprintf("user %d (%s): %s", ex.UserID, ex.UserName, ex.Reason))
+	}
+	return fmt.Sprintf("%s: %s", ErrNoSuitableUsers, strings.Join(reasons, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrNoSuitableUsers)
+func (e *NoSuitableUsersError) Unwrap() error {
+	return ErrNoSuitableUsers
+}
+
+// RequiredSkillsConstraint requires the user to have every skill of the task.
+// Related skills of the same category do not satisfy a requirement.
+type RequiredSkillsConstraint struct {
+	Normalizer *SkillNormalizer
+}
+
+// Check implements Constraint
+func (c RequiredSkillsConstraint) Check(task Task, user User) error {
+	missing := missingSkills(c.Normalizer, user.Skills, task.Skills)
+	if len(missing) > 0 {
+		return fmt.Errorf("missing skills: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// CapacityConstraint requires the task to fit into the user's remaining capacity
+type CapacityConstraint struct {
+	Capacity CapacityPolicy
+}
+
+// Check implements Constraint
+func (c CapacityConstraint) Check(task Task, user User) error {
+	if !c.Capacity.Fits(user, task) {
+		return fmt.Errorf(
+			"no capacity: load %d/%d%s, task needs %d",
+			user.CurrentLoad, user.MaxCapacity, c.Capacity.Unit(), c.Capacity.TaskCost(task),
+		)
+	}
+	return nil
+}
+
+// BlocklistConstraint keeps users away from projects they are blocklisted for
+type BlocklistConstraint struct {
+	blocked map[int]map[int]bool
+}
+
+// NewBlocklistConstraint creates a constraint from project ID to blocked user IDs
+func NewBlocklistConstraint(blocklist map[int][]int) *BlocklistConstraint {
+	blocked := make(map[int]map[int]bool, len(blocklist))
+	for projectID, userIDs := range blocklist {
+		blocked[projectID] = make(map[int]bool, len(userIDs))
+		for _, userID := range userIDs {
+			blocked[projectID][userID] = true
+		}
+	}
+	return &BlocklistConstraint{blocked: blocked}
+}
+
+// Check implements Constraint
+func (c *BlocklistConstraint) Check(task Task, user User) error {
+	if c.blocked[task.ProjectID][user.ID] {
+		return fmt.Errorf("blocklisted for project %d", task.ProjectID)
+	}
+	return nil
+}
+
+// filterEligible splits users into eligible ones and exclusions with reasons
+func filterEligible(constraints []Constraint, task Task, users []User) ([]User, []Exclusion) {
+	if len(constraints) == 0 {
+		return users, nil
+	}
+
+	eligible := make([]User, 0, len(users))
+	var exclusions []Exclusion
+
+	for _, user := range users {
+		if err := checkConstraints(constraints, task, user); err != nil {
+			exclusions = append(exclusions, Exclusion{
+				UserID:   user.ID,
+				UserName: user.Name,
+				Reason:   err.Error(),
+			})
+			continue
+		}
+		eligible = append(eligible, user)
+	}
+
+	return eligible, exclusions
+}
+
+// checkConstraints returns the first violated constraint
+func checkConstraints(constraints []Constraint, task Task, user User) error {
+	for _, constraint := range constraints {
+		if err := constraint.Check(task, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// missingSkills lists requirements the user lacks or holds below the minimum level
+func missingSkills(normalizer *SkillNormalizer, userSkills SkillSet, taskSkills []SkillRequirement) []string {
+	var missing []string
+
+	for _, requirement := range taskSkills {
+		skill, ok := normalizer.find(userSkills, requirement.Name)
+		switch {
+		case !ok:
+			missing = append(missing, requirement.Name)
+		case skill.Level < requirement.MinLevel:
+			missing = append(missing, fmt.Sprintf("%s (level %d < %d)", requirement.Name, skill.Level, requirement.MinLevel))
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredSkillsConstraint(t *testing.T) {
+	constraint := RequiredSkillsConstraint{}
+	task := Task{Skills: RequirementsFromNames("php", "laravel", "go")}
+
+	assert.NoError(t, constraint.Check(task, User{Skills: SkillsFromNames("PHP", "Laravel", "Go")}))
+
+	err := constraint.Check(task, User{Skills: SkillsFromNames("php")})
+	assert.EqualError(t, err, "missing skills: laravel, go")
+
+	assert.NoError(t, constraint.Check(Task{}, User{}), "no requirements")
+}
+
+func TestCapacityConstraint(t *testing.T) {
+	constraint := CapacityConstraint{Capacity: CapacityPolicy{Model: CapacityModelTasks}}
+
+	assert.NoError(t, constraint.Check(Task{}, User{CurrentLoad: 9, MaxCapacity: 10}))
+	assert.EqualError(t,
+		constraint.Check(Task{}, User{CurrentLoad: 10, MaxCapacity: 10}),
+		"no capacity: load 10/10, task needs 1",
+	)
+}
+
+func TestBlocklistConstraint(t *testing.T) {
+	constraint := NewBlocklistConstraint(map[int][]int{3: {7}})
+
+	assert.Error(t, constraint.Check(Task{ProjectID: 3}, User{ID: 7}))
+	assert.NoError(t, constraint.Check(Task{ProjectID: 3}, User{ID: 8}))
+	assert.NoError(t, constraint.Check(Task{ProjectID: 4}, User{ID: 7}))
+}
+
+func TestFindBestAssigneeWithConstraints(t *testing.T) {
+	ctx := context.Background()
+	constraints := []Constraint{
+		RequiredSkillsConstraint{},
+		CapacityConstraint{Capacity: DefaultCapacityPolicy()},
+		NewBlocklistConstraint(map[int][]int{1: {3}}),
+	}
+
+	t.Run("ineligible user cannot win even when alone", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, WithConstraints(constraints...))
+
+		users := []User{
+			{ID: 1, Name: "Designer", Skills: SkillsFromNames("figma"), CurrentLoad: 0, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		result, err := service.FindBestAssignee(ctx, Task{ID: 1, ProjectID: 1, Priority: 3, Skills: RequirementsFromNames("go")})
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrNoSuitableUsers))
+
+		var noUsers *NoSuitableUsersError
+		require.True(t, errors.As(err, &noUsers))
+		assert.Equal(t, []Exclusion{{UserID: 1, UserName: "Designer", Reason: "missing skills: go"}}, noUsers.Exclusions)
+	})
+
+	t.Run("reports a reason per excluded user", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, WithConstraints(constraints...))
+
+		users := []User{
+			{ID: 2, Name: "Full", Skills: SkillsFromNames("go"), CurrentLoad: 10, MaxCapacity: 10},
+			{ID: 3, Name: "Blocked", Skills: SkillsFromNames("go"), CurrentLoad: 0, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		_, err := service.FindBestAssignee(ctx, Task{ID: 1, ProjectID: 1, Priority: 3, Skills: RequirementsFromNames("go")})
+
+		var noUsers *NoSuitableUsersError
+		require.True(t, errors.As(err, &noUsers))
+		require.Len(t, noUsers.Exclusions, 2)
+		assert.Equal(t, "no capacity: load 10/10, task needs 1", noUsers.Exclusions[0].Reason)
+		assert.Equal(t, "blocklisted for project 1", noUsers.Exclusions[1].Reason)
+	})
+
+	t.Run("eligible users are ranked", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, WithConstraints(constraints...))
+
+		users := []User{
+			{ID: 1, Name: "Partial", Skills: SkillsFromNames("go"), CurrentLoad: 0, MaxCapacity: 10},
+			{ID: 2, Name: "Complete", Skills: SkillsFromNames("go", "grpc"), CurrentLoad: 9, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		candidates, err := service.RankCandidates(ctx, Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("go", "grpc")}, 5)
+
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, 2, candidates[0].UserID)
+	})
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format Laravel uses for date columns
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date that accepts both "YYYY-MM-DD" and RFC 3339 in JSON
+type Date struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Date) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		d.Time = time.Time{}
+		return nil
+	}
+
+	if t, err := time.Parse(DateLayout, value); err == nil {
+		d.Time = t
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: expected %s or RFC 3339", value, DateLayout)
+	}
+
+	d.Time = t
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + d.Format(DateLayout) + `"`), nil
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// FactorDeadline is the name of the deadline scoring factor
+const FactorDeadline = "deadline"
+
+// workingDaysPerWeek spreads weekly hours across Monday to Friday
+const workingDaysPerWeek = 5
+
+// DeadlineScorer favors users who can realistically finish the task before its due date.
+// Users are assumed to work through their queued hours before starting the new task.
+type DeadlineScorer struct {
+	capacity CapacityPolicy
+	now      func() time.Time
+}
+
+// NewDeadlineScorer creates a deadline scorer using the task size rules of the capacity policy
+func NewDeadlineScorer(capacity CapacityPolicy) *DeadlineScorer {
+	return &DeadlineScorer{
+		capacity: capacity,
+		now:      time.Now,
+	}
+}
+
+// Score implements Scorer.
+// Users who can finish in time score 0.5..1.0 depending on how much slack they keep,
+// users who cannot score 0.0..0.5 depending on how much of the task they would get done.
+func (s *DeadlineScorer) Score(task Task, user User) (float64, string) {
+	if task.DueDate == nil {
+		return 1.0, ""
+	}
+
+	taskHours := s.capacity.TaskHours(task)
+	available := s.availableHours(user, *task.DueDate)
+	needed := user.QueuedHours + taskHours
+
+	explanation := fmt.Sprintf(
+		"Deadline %s: %dh needed, %dh available",
+		task.DueDate.Format(DateLayout), needed, available,
+	)
+
+	if available <= 0 {
+		return 0.0, explanation
+	}
+
+	if needed <= available {
+		slack := float64(available-needed) / float64(available)
+		return 0.5 + 0.5*slack, explanation
+	}
+
+	if taskHours <= 0 {
+		return 0.0, explanation
+	}
+
+	doable := float64(available-user.QueuedHours) / float64(taskHours)
+	return 0.5 * clamp01(doable), explanation
+}
+
+// availableHours returns the working hours the user has from today until the end of the due date
+func (s *DeadlineScorer) availableHours(user User, dueDate time.Time) int {
+	days := workingDaysBetween(s.now(), dueDate)
+	return days * user.WeeklyHours / workingDaysPerWeek
+}
+
+// workingDaysBetween counts weekdays from today to the due date, both inclusive
+func workingDaysBetween(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := 0
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			days++
+		}
+	}
+
+	return days
+}
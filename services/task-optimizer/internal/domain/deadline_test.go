@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeadlineScorer(now time.Time) *DeadlineScorer {
+	scorer := NewDeadlineScorer(CapacityPolicy{DefaultTaskHours: 4})
+	scorer.now = func() time.Time { return now }
+	return scorer
+}
+
+func TestWorkingDaysBetween(t *testing.T) {
+	monday := time.Date(2025, 11, 24, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 1, workingDaysBetween(monday, monday), "today counts")
+	assert.Equal(t, 5, workingDaysBetween(monday, monday.AddDate(0, 0, 4)), "until friday")
+	assert.Equal(t, 5, workingDaysBetween(monday, monday.AddDate(0, 0, 6)), "weekend is skipped")
+	assert.Equal(t, 0, workingDaysBetween(monday, monday.AddDate(0, 0, -1)), "overdue")
+}
+
+func TestDeadlineScorer(t *testing.T) {
+	monday := time.Date(2025, 11, 24, 9, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+	scorer := newTestDeadlineScorer(monday)
+
+	// 5 working days of 8 hours
+	task := Task{EstimatedHours: 8, DueDate: &friday}
+
+	t.Run("no due date is neutral", func(t *testing.T) {
+		score, explanation := scorer.Score(Task{EstimatedHours: 8}, User{WeeklyHours: 40})
+		assert.Equal(t, 1.0, score)
+		assert.Empty(t, explanation)
+	})
+
+	t.Run("free user has most slack", func(t *testing.T) {
+		score, explanation := scorer.Score(task, User{WeeklyHours: 40})
+		assert.InDelta(t, 0.9, score, 1e-9)
+		assert.Equal(t, "Deadline 2025-11-28: 8h needed, 40h available", explanation)
+	})
+
+	t.Run("busy user still fits", func(t *testing.T) {
+		score, _ := scorer.Score(task, User{WeeklyHours: 40, QueuedHours: 32})
+		assert.InDelta(t, 0.5, score, 1e-9)
+	})
+
+	t.Run("overbooked user gets partial credit", func(t *testing.T) {
+		score, _ := scorer.Score(task, User{WeeklyHours: 40, QueuedHours: 36})
+		assert.InDelta(t, 0.25, score, 1e-9)
+	})
+
+	t.Run("user with no free time before deadline", func(t *testing.T) {
+		score, _ := scorer.Score(task, User{WeeklyHours: 40, QueuedHours: 60})
+		assert.Equal(t, 0.0, score)
+	})
+
+	t.Run("prefers free time over skills for near deadlines", func(t *testing.T) {
+		free, _ := scorer.Score(task, User{WeeklyHours: 40, QueuedHours: 0})
+		busy, _ := scorer.Score(task, User{WeeklyHours: 40, QueuedHours: 30})
+		assert.Greater(t, free, busy)
+	})
+}
+
+func TestTaskCreatedEventDueDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected *time.Time
+	}{
+		{"laravel date", `{"task_id":1,"due_date":"2025-11-30"}`, ptrTime(time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC))},
+		{"rfc 3339", `{"task_id":1,"due_date":"2025-11-30T00:00:00Z"}`, ptrTime(time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC))},
+		{"null", `{"task_id":1,"due_date":null}`, nil},
+		{"missing", `{"task_id":1}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event TaskCreatedEvent
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &event))
+
+			task := event.ToTask()
+			if tt.expected == nil {
+				assert.Nil(t, task.DueDate)
+				return
+			}
+			require.NotNil(t, task.DueDate)
+			assert.True(t, tt.expected.Equal(*task.DueDate))
+		})
+	}
+
+	var event TaskCreatedEvent
+	assert.Error(t, json.Unmarshal([]byte(`{"due_date":"next friday"}`), &event))
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// FactorHistory is the name of the historical performance scoring factor
+const FactorHistory = "history"
+
+// neutralReliability is used when nobody on the team has enough history yet
+const neutralReliability = 0.5
+
+// DeliveryStats counts completed tasks and how many of them were done on estimate
+type DeliveryStats struct {
+	Completed  int
+	OnEstimate int
+}
+
+// Add returns the sum of both stats
+func (d DeliveryStats) Add(other DeliveryStats) DeliveryStats {
+	return DeliveryStats{
+		Completed:  d.Completed + other.Completed,
+		OnEstimate: d.OnEstimate + other.OnEstimate,
+	}
+}
+
+// Reliability returns the share of completed tasks finished on estimate
+func (d DeliveryStats) Reliability() float64 {
+	if d.Completed == 0 {
+		return 0.0
+	}
+	return float64(d.OnEstimate) / float64(d.Completed)
+}
+
+// PerformanceStats is the delivery history of a user, overall and per skill
+type PerformanceStats struct {
+	Overall DeliveryStats
+	BySkill map[string]DeliveryStats
+}
+
+// HistoryScorer rewards users who reliably finished similar tasks on estimate.
+// Similar tasks are the ones sharing a required skill. Users with fewer than
+// MinSamples completed tasks get the team average, so newcomers are not penalized.
+type HistoryScorer struct {
+	repo       PerformanceRepository
+	normalizer *SkillNormalizer
+	minSamples int
+	lookback   time.Duration
+	now        func() time.Time
+}
+
+// NewHistoryScorer creates a history scorer reading the last lookback of completed tasks
+func NewHistoryScorer(
+	repo PerformanceRepository,
+	normalizer *SkillNormalizer,
+	minSamples int,
+	lookback time.Duration,
+) *HistoryScorer {
+	if minSamples < 1 {
+		minSamples = 1
+	}
+
+	return &HistoryScorer{
+		repo:       repo,
+		normalizer: normalizer,
+		minSamples: minSamples,
+		lookback:   lookback,
+		now:        time.Now,
+	}
+}
+
+// Score implements Scorer. Without prepared history every user is neutral.
+func (s *HistoryScorer) Score(_ Task, _ User) (float64, string) {
+	return neutralReliability, ""
+}
+
+// Prepare implements Preparer by loading the delivery history of the candidates
+func (s *HistoryScorer) Prepare(ctx context.Context, _ Task, users []User) (Scorer, error) {
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	stats, err := s.repo.GetPerformanceStats(ctx, userIDs, s.now().Add(-s.lookback))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get performance stats: %w", err)
+	}
+
+	return newPreparedHistoryScorer(stats, s.normalizer, s.minSamples), nil
+}
+
+// preparedHistoryScorer scores users against history loaded for one scoring run
+type preparedHistoryScorer struct {
+	stats       map[int]PerformanceStats
+	normalizer  *SkillNormalizer
+	minSamples  int
+	teamAverage float64
+}
+
+func newPreparedHistoryScorer(
+	stats map[int]PerformanceStats,
+	normalizer *SkillNormalizer,
+	minSamples int,
+) *preparedHistoryScorer {
+	s := &preparedHistoryScorer{
+		stats:       make(map[int]PerformanceStats, len(stats)),
+		normalizer:  normalizer,
+		minSamples:  minSamples,
+		teamAverage: neutralReliability,
+	}
+
+	// Skill names in task_logs are free text, so merge them by canonical name
+	experienced := 0
+	total := 0.0
+	for userID, userStats := range stats {
+		bySkill := make(map[string]DeliveryStats, len(userStats.BySkill))
+		for name, skillStats := range userStats.BySkill {
+			canonical := normalizer.Canonical(name)
+			bySkill[canonical] = bySkill[canonical].Add(skillStats)
+		}
+		s.stats[userID] = PerformanceStats{Overall: userStats.Overall, BySkill: bySkill}
+
+		if userStats.Overall.Completed >= minSamples {
+			experienced++
+			total += userStats.Overall.Reliability()
+		}
+	}
+
+	if experienced > 0 {
+		s.teamAverage = total / float64(experienced)
+	}
+
+	return s
+}
+
+// Score implements Scorer.
+// History on the task skills is preferred, overall history is used when it is too thin.
+func (s *preparedHistoryScorer) Score(task Task, user User) (float64, string) {
+	userStats := s.stats[user.ID]
+
+	similar := DeliveryStats{}
+	for _, requirement := range task.Skills {
+		similar = similar.Add(userStats.BySkill[s.normalizer.Canonical(requirement.Name)])
+	}
+
+	if similar.Completed >= s.minSamples {
+		return similar.Reliability(), fmt.Sprintf(
+			"History: %d/%d similar tasks on estimate", similar.OnEstimate, similar.Completed,
+		)
+	}
+
+	if userStats.Overall.Completed >= s.minSamples {
+		return userStats.Overall.Reliability(), fmt.Sprintf(
+			"History: %d/%d tasks on estimate", userStats.Overall.OnEstimate, userStats.Overall.Completed,
+		)
+	}
+
+	return s.teamAverage, fmt.Sprintf("History: %d tasks, team average", userStats.Overall.Completed)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPerformanceRepository is a mock implementation of PerformanceRepository
+type MockPerformanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPerformanceRepository) GetPerformanceStats(
+	ctx context.Context,
+	userIDs []int,
+	since time.Time,
+) (map[int]PerformanceStats, error) {
+	args := m.Called(ctx, userIDs, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]PerformanceStats), args.Error(1)
+}
+
+func TestHistoryScorer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 11, 24, 9, 0, 0, 0, time.UTC)
+
+	stats := map[int]PerformanceStats{
+		// Reliable on Go work, sloppy elsewhere
+		1: {
+			Overall: DeliveryStats{Completed: 10, OnEstimate: 5},
+			BySkill: map[string]DeliveryStats{"golang": {Completed: 4, OnEstimate: 4}},
+		},
+		// Enough history overall, none on Go
+		2: {
+			Overall: DeliveryStats{Completed: 4, OnEstimate: 1},
+		},
+		// Newcomer
+		3: {
+			Overall: DeliveryStats{Completed: 1, OnEstimate: 0},
+		},
+	}
+
+	repo := new(MockPerformanceRepository)
+	repo.On("GetPerformanceStats", ctx, []int{1, 2, 3, 4}, now.AddDate(0, 0, -30)).Return(stats, nil)
+
+	normalizer := NewSkillNormalizer(nil, map[string]string{"golang": "go"}, 0)
+	scorer := NewHistoryScorer(repo, normalizer, 3, 30*24*time.Hour)
+	scorer.now = func() time.Time { return now }
+
+	users := []User{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	task := Task{Skills: RequirementsFromNames("Go")}
+
+	prepared, err := scorer.Prepare(ctx, task, users)
+	require.NoError(t, err)
+
+	t.Run("similar tasks are preferred", func(t *testing.T) {
+		score, explanation := prepared.Score(task, users[0])
+		assert.Equal(t, 1.0, score)
+		assert.Equal(t, "History: 4/4 similar tasks on estimate", explanation)
+	})
+
+	t.Run("overall history when similar tasks are too few", func(t *testing.T) {
+		score, explanation := prepared.Score(task, users[1])
+		assert.Equal(t, 0.25, score)
+		assert.Equal(t, "History: 1/4 tasks on estimate", explanation)
+	})
+
+	t.Run("newcomers get the team average", func(t *testing.T) {
+		score, explanation := prepared.Score(task, users[2])
+		assert.InDelta(t, (0.5+0.25)/2, score, 1e-9)
+		assert.Equal(t, "History: 1 tasks, team average", explanation)
+
+		score, _ = prepared.Score(task, users[3])
+		assert.InDelta(t, (0.5+0.25)/2, score, 1e-9)
+	})
+
+	repo.AssertExpectations(t)
+}
+
+func TestHistoryScorerWithoutHistory(t *testing.T) {
+	prepared := newPreparedHistoryScorer(map[int]PerformanceStats{}, nil, 3)
+
+	score, _ := prepared.Score(Task{}, User{ID: 1})
+	assert.Equal(t, neutralReliability, score)
+}
+
+func TestFindBestAssigneePreparesScorer(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(MockUserRepository)
+	performanceRepo := new(MockPerformanceRepository)
+
+	registry := DefaultFactorRegistry()
+	registry[FactorHistory] = NewHistoryScorer(performanceRepo, nil, 1, time.Hour)
+	scorer, err := registry.Build([]FactorWeight{{Name: FactorHistory, Weight: 1}})
+	require.NoError(t, err)
+
+	service := NewOptimizerService(userRepo, WithScorer(scorer))
+
+	users := []User{
+		{ID: 1, Name: "Late", MaxCapacity: 10},
+		{ID: 2, Name: "Punctual", MaxCapacity: 10},
+	}
+	userRepo.On("GetActiveUsers", ctx).Return(users, nil)
+	performanceRepo.On("GetPerformanceStats", ctx, []int{1, 2}, mock.Anything).Return(map[int]PerformanceStats{
+		1: {Overall: DeliveryStats{Completed: 5, OnEstimate: 1}},
+		2: {Overall: DeliveryStats{Completed: 5, OnEstimate: 5}},
+	}, nil)
+
+	result, err := service.FindBestAssignee(ctx, Task{ID: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.UserID)
+	assert.Equal(t, "History: 5/5 tasks on estimate", result.Reason)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "math"
+
+// infeasibleCost marks task/slot pairs that must never be matched.
+// A finite value keeps the potentials of the Hungarian algorithm well defined.
+const infeasibleCost = 1e9
+
+// solveAssignment solves the rectangular assignment problem with the Hungarian algorithm.
+// cost has one row per task and at least as many columns as rows.
+// It returns the column assigned to each row, minimizing the total cost.
+func solveAssignment(cost [][]float64) []int {
+	n := len(cost)
+	if n == 0 {
+		return nil
+	}
+	m := len(cost[0])
+
+	// Potentials and matching use 1-based indices, column 0 is a virtual start
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	match := make([]int, m+1)
+	way := make([]int, m+1)
+
+	for row := 1; row <= n; row++ {
+		match[0] = row
+		col := 0
+
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+
+		for {
+			used[col] = true
+			current := match[col]
+			delta := math.Inf(1)
+			next := 0
+
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+
+				reduced := cost[current-1][j-1] - u[current] - v[j]
+				if reduced < minv[j] {
+					minv[j] = reduced
+					way[j] = col
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					next = j
+				}
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			col = next
+			if match[col] == 0 {
+				break
+			}
+		}
+
+		// Flip the augmenting path
+		for col != 0 {
+			prev := way[col]
+			match[col] = match[prev]
+			col = prev
+		}
+	}
+
+	assignment := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if match[j] != 0 {
+			assignment[match[j]-1] = j - 1
+		}
+	}
+
+	return assignment
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository defines methods for accessing user data
 type UserRepository interface {
@@ -10,8 +13,62 @@ type UserRepository interface {
 	// GetUserByID returns a user by ID
 	GetUserByID(ctx context.Context, id int) (*User, error)
 
-	// UpdateUserLoad updates the current load of a user
-	UpdateUserLoad(ctx context.Context, userID int, increment int) error
+	// UpdateUserLoad books the task on the user in the load ledger, moving it from any previous assignee
+	UpdateUserLoad(ctx context.Context, entry LoadEntry) error
+
+	// ReleaseUserLoad frees the capacity the task holds in the load ledger and returns
+	// the user it was booked on, or 0 when the task was not booked or already released.
+	ReleaseUserLoad(ctx context.Context, taskID int) (int, error)
+}
+
+// SkillRepository defines methods for accessing the skills catalog
+type SkillRepository interface {
+	// GetSkills returns all skills of the catalog
+	GetSkills(ctx context.Context) ([]SkillDefinition, error)
+}
+
+// PerformanceRepository defines methods for accessing delivery history
+type PerformanceRepository interface {
+	// GetPerformanceStats returns the delivery history since the given time, keyed by user ID
+	GetPerformanceStats(ctx context.Context, userIDs []int, since time.Time) (map[int]PerformanceStats, error)
+}
+
+// ProjectActivityRepository defines methods for accessing project involvement of users
+type ProjectActivityRepository interface {
+	// GetProjectActivity returns open and since-completed project tasks, keyed by user ID
+	GetProjectActivity(ctx context.Context, projectID int, userIDs []int, since time.Time) (map[int]ProjectActivity, error)
+}
+
+// AvailabilityRepository defines methods for accessing working schedules and absences
+type AvailabilityRepository interface {
+	// GetAvailability returns schedules and absences overlapping the period, keyed by user ID
+	GetAvailability(ctx context.Context, userIDs []int, from, to time.Time) (map[int]Availability, error)
+}
+
+// AssignmentRepository defines methods for storing decided assignments
+type AssignmentRepository interface {
+	// GetAssignment returns the assignment decided for a task or ErrAssignmentNotFound
+	GetAssignment(ctx context.Context, taskID int) (*TaskAssignedEvent, error)
+
+	// CreateAssignment stores the assignment, books its load and queues its task.assigned event
+	// in one transaction unless the task already has an assignment.
+	// It returns the assignment stored for the task and whether this call created it.
+	CreateAssignment(ctx context.Context, event TaskAssignedEvent, load LoadEntry) (stored *TaskAssignedEvent, created bool, err error)
+}
+
+// OutboxRepository defines methods for the transactional outbox
+type OutboxRepository interface {
+	// Enqueue adds a message to the outbox
+	Enqueue(ctx context.Context, message OutboxMessage) error
+
+	// ClaimPending locks up to limit unsent messages for the lease duration, oldest first
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+
+	// MarkSent records that the message was confirmed by the broker
+	MarkSent(ctx context.Context, id int64) error
+
+	// MarkFailed records a failed attempt and keeps the message from being claimed until retryAfter has passed
+	MarkFailed(ctx context.Context, id int64, reason string, retryAfter time.Duration) error
 }
 
 // EventPublisher defines methods for publishing events
@@ -19,3 +76,10 @@ type EventPublisher interface {
 	// PublishTaskAssigned publishes task assignment event
 	PublishTaskAssigned(ctx context.Context, event TaskAssignedEvent) error
 }
+
+// MessagePublisher defines methods for publishing raw messages
+type MessagePublisher interface {
+	// Publish publishes the message and waits until the broker confirms it.
+	// A message the broker did not accept is reported as a *PublishError.
+	Publish(ctx context.Context, routingKey string, body []byte) error
+}
@@ -1,16 +1,61 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Task represents a task that needs to be assigned
 type Task struct {
-	ID          int
-	Title       string
-	Description string
-	Priority    int
-	ProjectID   int
-	Skills      []string
-	CreatedAt   time.Time
+	ID             int
+	Title          string
+	Description    string
+	Priority       int
+	ProjectID      int
+	Skills         []SkillRequirement
+	EstimatedHours int
+	DueDate        *time.Time
+	CreatedAt      time.Time
+}
+
+// SkillNames returns the names of the required skills
+func (t Task) SkillNames() []string {
+	names := make([]string, 0, len(t.Skills))
+	for _, skill := range t.Skills {
+		names = append(names, skill.Name)
+	}
+	return names
+}
+
+// ValidateDraft checks the fields scoring depends on.
+// A draft may lack the ID and title of a task that is still being written.
+func (t Task) ValidateDraft() error {
+	if t.Priority < 1 || t.Priority > 5 {
+		return fmt.Errorf("priority must be between 1 and 5, got: %d", t.Priority)
+	}
+
+	if t.ProjectID <= 0 {
+		return fmt.Errorf("invalid project_id: %d", t.ProjectID)
+	}
+
+	if t.EstimatedHours < 0 {
+		return fmt.Errorf("estimated_hours cannot be negative, got: %d", t.EstimatedHours)
+	}
+
+	return nil
+}
+
+// Validate checks that the task can be assigned
+func (t Task) Validate() error {
+	if t.ID <= 0 {
+		return fmt.Errorf("invalid task_id: %d", t.ID)
+	}
+
+	if t.Title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+
+	return t.ValidateDraft()
 }
 
 // User represents a potential assignee
@@ -19,9 +64,11 @@ type User struct {
 	Name        string
 	Email       string
 	Role        string
-	Skills      []string
+	Skills      SkillSet
 	CurrentLoad int
 	MaxCapacity int
+	QueuedHours int
+	WeeklyHours int
 }
 
 // AssignmentResult contains the result of task assignment calculation
@@ -32,38 +79,111 @@ type AssignmentResult struct {
 	SkillScore    float64
 	LoadScore     float64
 	PriorityBonus float64
+	Factors       []FactorScore
 	Reason        string
 }
 
+// Task event routing keys
+const (
+	EventTaskCreated    = "task.created"
+	EventTaskAssigned   = "task.assigned"
+	EventTaskCompleted  = "task.completed"
+	EventTaskCancelled  = "task.cancelled"
+	EventTaskReassigned = "task.reassigned"
+	EventTaskUpdated    = "task.updated"
+)
+
 // TaskCreatedEvent represents incoming event from RabbitMQ
 type TaskCreatedEvent struct {
-	TaskID      int       `json:"task_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Priority    int       `json:"priority"`
-	ProjectID   int       `json:"project_id"`
-	Skills      []string  `json:"skills"`
-	CreatedAt   time.Time `json:"created_at"`
+	TaskID         int                `json:"task_id"`
+	Title          string             `json:"title"`
+	Description    string             `json:"description"`
+	Priority       int                `json:"priority"`
+	ProjectID      int                `json:"project_id"`
+	Skills         []SkillRequirement `json:"skills"`
+	EstimatedHours int                `json:"estimated_hours"`
+	DueDate        *Date              `json:"due_date,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
 }
 
 // TaskAssignedEvent represents outgoing event to RabbitMQ
 type TaskAssignedEvent struct {
-	TaskID     int       `json:"task_id"`
-	AssigneeID int       `json:"assignee_id"`
-	Score      float64   `json:"score"`
-	Reason     string    `json:"reason"`
-	AssignedAt time.Time `json:"assigned_at"`
+	TaskID       int                `json:"task_id"`
+	AssigneeID   int                `json:"assignee_id"`
+	Score        float64            `json:"score"`
+	Reason       string             `json:"reason"`
+	Alternatives []CandidateSummary `json:"alternatives,omitempty"`
+	AssignedAt   time.Time          `json:"assigned_at"`
+}
+
+// TaskLifecycleEvent represents incoming task.completed, task.cancelled,
+// task.reassigned and task.updated events from RabbitMQ
+type TaskLifecycleEvent struct {
+	TaskID             int       `json:"task_id"`
+	AssigneeID         int       `json:"assignee_id"`
+	PreviousAssigneeID int       `json:"previous_assignee_id,omitempty"`
+	Status             string    `json:"status"`
+	EstimatedHours     int       `json:"estimated_hours"`
+	OccurredAt         time.Time `json:"occurred_at"`
+}
+
+// LoadEntry returns the capacity the task holds on its current assignee
+func (e TaskLifecycleEvent) LoadEntry() LoadEntry {
+	return LoadEntry{
+		TaskID:         e.TaskID,
+		UserID:         e.AssigneeID,
+		EstimatedHours: e.EstimatedHours,
+	}
+}
+
+// LoadEntry is a task booked on a user's capacity in the load ledger
+type LoadEntry struct {
+	TaskID         int
+	UserID         int
+	EstimatedHours int
+}
+
+// CandidateSummary describes a runner-up candidate with its factor breakdown
+type CandidateSummary struct {
+	UserID        int     `json:"user_id"`
+	UserName      string  `json:"user_name"`
+	Score         float64 `json:"score"`
+	SkillScore    float64 `json:"skill_score"`
+	LoadScore     float64 `json:"load_score"`
+	PriorityBonus float64 `json:"priority_bonus"`
+	Reason        string  `json:"reason"`
+}
+
+// Summary converts an assignment result to its event representation
+func (r AssignmentResult) Summary() CandidateSummary {
+	return CandidateSummary{
+		UserID:        r.UserID,
+		UserName:      r.UserName,
+		Score:         r.TotalScore,
+		SkillScore:    r.SkillScore,
+		LoadScore:     r.LoadScore,
+		PriorityBonus: r.PriorityBonus,
+		Reason:        r.Reason,
+	}
 }
 
 // ToTask converts TaskCreatedEvent to Task domain model
 func (e TaskCreatedEvent) ToTask() Task {
+	var dueDate *time.Time
+	if e.DueDate != nil && !e.DueDate.IsZero() {
+		date := e.DueDate.Time
+		dueDate = &date
+	}
+
 	return Task{
-		ID:          e.TaskID,
-		Title:       e.Title,
-		Description: e.Description,
-		Priority:    e.Priority,
-		ProjectID:   e.ProjectID,
-		Skills:      e.Skills,
-		CreatedAt:   e.CreatedAt,
+		ID:             e.TaskID,
+		Title:          e.Title,
+		Description:    e.Description,
+		Priority:       e.Priority,
+		ProjectID:      e.ProjectID,
+		Skills:         e.Skills,
+		EstimatedHours: e.EstimatedHours,
+		DueDate:        dueDate,
+		CreatedAt:      e.CreatedAt,
 	}
 }
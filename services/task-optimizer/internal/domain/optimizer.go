@@ -5,92 +5,260 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	"strings"
 )
 
 var (
-	ErrNoSuitableUsers = errors.New("no suitable users found")
+	ErrNoSuitableUsers      = errors.New("no suitable users found")
+	ErrAssignmentNotFound   = errors.New("assignment not found")
+	ErrInvalidEvent         = errors.New("invalid event")
+	ErrCandidateUnavailable = errors.New("candidate no longer eligible")
+	ErrUserNotFound         = errors.New("user not found")
 )
 
 // OptimizerService contains the core business logic for task assignment
 type OptimizerService struct {
-	userRepo UserRepository
+	userRepo     UserRepository
+	scorer       *CompositeScorer
+	capacity     CapacityPolicy
+	constraints  []Constraint
+	availability *AvailabilityPolicy
+}
+
+// OptimizerOption configures an OptimizerService
+type OptimizerOption func(*OptimizerService)
+
+// WithScorer replaces the default scoring formula
+func WithScorer(scorer *CompositeScorer) OptimizerOption {
+	return func(s *OptimizerService) {
+		s.scorer = scorer
+	}
+}
+
+// WithCapacity sets the capacity policy used to check whether tasks fit
+func WithCapacity(capacity CapacityPolicy) OptimizerOption {
+	return func(s *OptimizerService) {
+		s.capacity = capacity
+	}
+}
+
+// WithConstraints sets the eligibility constraints checked before scoring
+func WithConstraints(constraints ...Constraint) OptimizerOption {
+	return func(s *OptimizerService) {
+		s.constraints = constraints
+	}
+}
+
+// WithAvailability sets the policy for users absent during the task
+func WithAvailability(availability *AvailabilityPolicy) OptimizerOption {
+	return func(s *OptimizerService) {
+		s.availability = availability
+	}
 }
 
 // NewOptimizerService creates a new optimizer service
-func NewOptimizerService(userRepo UserRepository) *OptimizerService {
-	return &OptimizerService{
+func NewOptimizerService(userRepo UserRepository, opts ...OptimizerOption) *OptimizerService {
+	s := &OptimizerService{
 		userRepo: userRepo,
+		capacity: DefaultCapacityPolicy(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	if s.scorer == nil {
+		s.scorer = defaultScorer()
+	}
+
+	return s
 }
 
 // FindBestAssignee finds the best user to assign a task to
 func (s *OptimizerService) FindBestAssignee(ctx context.Context, task Task) (*AssignmentResult, error) {
+	candidates, err := s.RankCandidates(ctx, task, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	best := candidates[0]
+	return &best, nil
+}
+
+// RankCandidates returns up to n users ordered from best to worst score.
+// A non-positive n returns every candidate.
+func (s *OptimizerService) RankCandidates(ctx context.Context, task Task, n int) ([]AssignmentResult, error) {
+	scores, exclusions, err := s.rank(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scores) == 0 {
+		return nil, &NoSuitableUsersError{Exclusions: exclusions}
+	}
+
+	if n > 0 && n < len(scores) {
+		scores = scores[:n]
+	}
+
+	return scores, nil
+}
+
+// Explanation describes how a single user fares for a task
+type Explanation struct {
+	// Result is the user's score, nil when the user is not a candidate
+	Result *AssignmentResult
+	// Rank is the user's 1-based position among the candidates, 0 when not a candidate
+	Rank       int
+	Candidates int
+	// Exclusion tells why the user is not a candidate
+	Exclusion *Exclusion
+}
+
+// ExplainCandidate ranks the task and reports the score, rank or exclusion of one user
+func (s *OptimizerService) ExplainCandidate(ctx context.Context, task Task, userID int) (*Explanation, error) {
+	scores, exclusions, err := s.rank(ctx, task)
+	if err != nil && !errors.Is(err, ErrNoSuitableUsers) {
+		return nil, err
+	}
+
+	for i := range scores {
+		if scores[i].UserID == userID {
+			return &Explanation{Result: &scores[i], Rank: i + 1, Candidates: len(scores)}, nil
+		}
+	}
+
+	for i := range exclusions {
+		if exclusions[i].UserID == userID {
+			return &Explanation{Exclusion: &exclusions[i], Candidates: len(scores)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrUserNotFound, userID)
+}
+
+// rank scores the eligible users from best to worst and tells why the others were left out
+func (s *OptimizerService) rank(ctx context.Context, task Task) ([]AssignmentResult, []Exclusion, error) {
 	users, err := s.userRepo.GetActiveUsers(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
+		return nil, nil, fmt.Errorf("failed to get users: %w", err)
 	}
 
 	if len(users) == 0 {
-		return nil, ErrNoSuitableUsers
+		return nil, nil, ErrNoSuitableUsers
+	}
+
+	eligible, exclusions := filterEligible(s.constraints, task, users)
+
+	availability, err := s.availability.Check(ctx, task, eligible)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+
+	eligible, absent := availability.filter(eligible)
+	exclusions = append(exclusions, absent...)
+
+	if len(eligible) == 0 {
+		return nil, exclusions, nil
+	}
+
+	scorer, err := s.scorer.Prepare(ctx, task, eligible)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare scoring: %w", err)
 	}
 
-	scores := s.calculateScores(task, users)
+	scores := calculateScores(scorer, task, eligible)
+	undiscounted := availability.apply(scores)
 
-	sort.Slice(scores, func(i, j int) bool {
+	// Stable sort keeps repository order (by user ID) between equal scores
+	sort.SliceStable(scores, func(i, j int) bool {
 		return scores[i].TotalScore > scores[j].TotalScore
 	})
 
-	best := scores[0]
-	return &best, nil
+	if skipped := availability.skippedReason(scores[0], absent, scores, undiscounted); skipped != "" {
+		scores[0].Reason = appendReason(scores[0].Reason, skipped)
+	}
+
+	return scores, exclusions, nil
+}
+
+// ConfirmCandidate reloads the user and checks the eligibility constraints again.
+// Called right before an assignment is stored, it notices capacity taken by assignments
+// committed since the candidates were ranked when capacity is one of the constraints.
+// Without constraints every ranked user stays eligible, so the user is not reloaded.
+func (s *OptimizerService) ConfirmCandidate(ctx context.Context, task Task, userID int) error {
+	if len(s.constraints) == 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := checkConstraints(s.constraints, task, *user); err != nil {
+		return fmt.Errorf("%w: user %d: %v", ErrCandidateUnavailable, userID, err)
+	}
+
+	return nil
 }
 
 // calculateScores calculates assignment scores for all users
-func (s *OptimizerService) calculateScores(task Task, users []User) []AssignmentResult {
+func calculateScores(scorer *CompositeScorer, task Task, users []User) []AssignmentResult {
 	results := make([]AssignmentResult, 0, len(users))
 
 	for _, user := range users {
-		skillScore := calculateSkillMatch(user.Skills, task.Skills)
-		loadScore := calculateLoadScore(user.CurrentLoad, user.MaxCapacity)
-		priorityBonus := calculatePriorityBonus(task.Priority)
-
-		totalScore := (skillScore * 0.4) + (loadScore * 0.4) + (priorityBonus * 0.2)
-
-		result := AssignmentResult{
-			UserID:        user.ID,
-			UserName:      user.Name,
-			TotalScore:    totalScore,
-			SkillScore:    skillScore,
-			LoadScore:     loadScore,
-			PriorityBonus: priorityBonus,
-			Reason: fmt.Sprintf(
-				"Skill match: %.0f%%, Load: %d/%d, Priority: %d",
-				skillScore*100, user.CurrentLoad, user.MaxCapacity, task.Priority,
-			),
-		}
-
-		results = append(results, result)
+		results = append(results, score(scorer, task, user))
 	}
 
 	return results
 }
 
-func calculateSkillMatch(userSkills, taskSkills []string) float64 {
+// score evaluates a single user for a task
+func score(scorer *CompositeScorer, task Task, user User) AssignmentResult {
+	totalScore, breakdown := scorer.Evaluate(task, user)
+
+	return AssignmentResult{
+		UserID:        user.ID,
+		UserName:      user.Name,
+		TotalScore:    totalScore,
+		SkillScore:    factorValue(breakdown, FactorSkill),
+		LoadScore:     factorValue(breakdown, FactorLoad),
+		PriorityBonus: factorValue(breakdown, FactorPriority),
+		Factors:       breakdown,
+		Reason:        explainFactors(breakdown),
+	}
+}
+
+// defaultScorer builds the scorer for the original hard-coded formula
+func defaultScorer() *CompositeScorer {
+	scorer, err := NewCompositeScorerFromWeights(DefaultFactorWeights())
+	if err != nil {
+		panic(fmt.Sprintf("invalid default scoring factors: %v", err))
+	}
+	return scorer
+}
+
+// calculateSkillMatch returns the weighted share of requirements the user satisfies.
+// Users below the minimum level of a skill get partial credit for it.
+func calculateSkillMatch(userSkills SkillSet, taskSkills []SkillRequirement) float64 {
+	return matchSkills(nil, userSkills, taskSkills)
+}
+
+// matchSkills is calculateSkillMatch with names resolved by the normalizer
+func matchSkills(normalizer *SkillNormalizer, userSkills SkillSet, taskSkills []SkillRequirement) float64 {
 	if len(taskSkills) == 0 {
 		return 1.0
 	}
 
-	matches := 0
-	for _, taskSkill := range taskSkills {
-		for _, userSkill := range userSkills {
-			if strings.EqualFold(taskSkill, userSkill) {
-				matches++
-				break
-			}
-		}
+	matched := 0.0
+	total := 0.0
+	for _, requirement := range taskSkills {
+		weight := requirement.EffectiveWeight()
+		total += weight
+		matched += weight * normalizer.match(userSkills, requirement)
 	}
 
-	return float64(matches) / float64(len(taskSkills))
+	return matched / total
 }
 
 func calculateLoadScore(currentLoad, maxCapacity int) float64 {
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,11 +27,16 @@ func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*User, er
 	return args.Get(0).(*User), args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateUserLoad(ctx context.Context, userID int, increment int) error {
-	args := m.Called(ctx, userID, increment)
+func (m *MockUserRepository) UpdateUserLoad(ctx context.Context, entry LoadEntry) error {
+	args := m.Called(ctx, entry)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ReleaseUserLoad(ctx context.Context, taskID int) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestCalculateSkillMatch(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -78,7 +84,7 @@ func TestCalculateSkillMatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateSkillMatch(tt.userSkills, tt.taskSkills)
+			result := calculateSkillMatch(SkillsFromNames(tt.userSkills...), RequirementsFromNames(tt.taskSkills...))
 			assert.InDelta(t, tt.expected, result, 0.01, tt.description)
 		})
 	}
@@ -169,14 +175,14 @@ func TestFindBestAssignee(t *testing.T) {
 			{
 				ID:          1,
 				Name:        "Expert",
-				Skills:      []string{"php", "laravel", "go"},
+				Skills:      SkillsFromNames("php", "laravel", "go"),
 				CurrentLoad: 2,
 				MaxCapacity: 10,
 			},
 			{
 				ID:          2,
 				Name:        "Junior",
-				Skills:      []string{"php"},
+				Skills:      SkillsFromNames("php"),
 				CurrentLoad: 1,
 				MaxCapacity: 10,
 			},
@@ -186,7 +192,7 @@ func TestFindBestAssignee(t *testing.T) {
 			ID:       1,
 			Title:    "Complex task",
 			Priority: 5,
-			Skills:   []string{"php", "laravel"},
+			Skills:   RequirementsFromNames("php", "laravel"),
 		}
 
 		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
@@ -208,14 +214,14 @@ func TestFindBestAssignee(t *testing.T) {
 			{
 				ID:          1,
 				Name:        "Busy",
-				Skills:      []string{"php", "laravel"},
+				Skills:      SkillsFromNames("php", "laravel"),
 				CurrentLoad: 8,
 				MaxCapacity: 10,
 			},
 			{
 				ID:          2,
 				Name:        "Available",
-				Skills:      []string{"php", "laravel"},
+				Skills:      SkillsFromNames("php", "laravel"),
 				CurrentLoad: 1,
 				MaxCapacity: 10,
 			},
@@ -224,7 +230,7 @@ func TestFindBestAssignee(t *testing.T) {
 		task := Task{
 			ID:       1,
 			Priority: 3,
-			Skills:   []string{"php"},
+			Skills:   RequirementsFromNames("php"),
 		}
 
 		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
@@ -261,14 +267,14 @@ func TestCalculateScores(t *testing.T) {
 		{
 			ID:          1,
 			Name:        "User 1",
-			Skills:      []string{"php", "laravel"},
+			Skills:      SkillsFromNames("php", "laravel"),
 			CurrentLoad: 0,
 			MaxCapacity: 10,
 		},
 		{
 			ID:          2,
 			Name:        "User 2",
-			Skills:      []string{"php"},
+			Skills:      SkillsFromNames("php"),
 			CurrentLoad: 5,
 			MaxCapacity: 10,
 		},
@@ -276,10 +282,10 @@ func TestCalculateScores(t *testing.T) {
 
 	task := Task{
 		Priority: 5,
-		Skills:   []string{"php", "laravel"},
+		Skills:   RequirementsFromNames("php", "laravel"),
 	}
 
-	scores := service.calculateScores(task, users)
+	scores := calculateScores(service.scorer, task, users)
 
 	assert.Len(t, scores, 2)
 
@@ -292,3 +298,170 @@ func TestCalculateScores(t *testing.T) {
 	assert.Equal(t, 0.5, scores[1].SkillScore)
 	assert.Equal(t, 0.5, scores[1].LoadScore)
 }
+
+func TestRankCandidates(t *testing.T) {
+	ctx := context.Background()
+
+	users := []User{
+		{ID: 1, Name: "Busy", Skills: SkillsFromNames("php"), CurrentLoad: 8, MaxCapacity: 10},
+		{ID: 2, Name: "Available", Skills: SkillsFromNames("php"), CurrentLoad: 1, MaxCapacity: 10},
+		{ID: 3, Name: "Idle", Skills: SkillsFromNames("php"), CurrentLoad: 0, MaxCapacity: 10},
+	}
+	task := Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("php")}
+
+	t.Run("returns top n ordered by score", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		candidates, err := service.RankCandidates(ctx, task, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 2)
+		assert.Equal(t, 3, candidates[0].UserID)
+		assert.Equal(t, 2, candidates[1].UserID)
+		assert.Equal(t, 0.9, candidates[1].LoadScore)
+		assert.Len(t, candidates[1].Factors, 3)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("non-positive n returns everyone", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		candidates, err := service.RankCandidates(ctx, task, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 3)
+		assert.Equal(t, 1, candidates[2].UserID)
+	})
+
+	t.Run("equal scores keep repository order", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+		twins := []User{
+			{ID: 5, Name: "First", CurrentLoad: 0, MaxCapacity: 10},
+			{ID: 6, Name: "Second", CurrentLoad: 0, MaxCapacity: 10},
+		}
+		mockRepo.On("GetActiveUsers", ctx).Return(twins, nil)
+
+		candidates, err := service.RankCandidates(ctx, Task{Priority: 1}, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{5, 6}, []int{candidates[0].UserID, candidates[1].UserID})
+	})
+}
+
+func TestConfirmCandidate(t *testing.T) {
+	ctx := context.Background()
+	task := Task{ID: 1, Priority: 3}
+	capacity := WithConstraints(CapacityConstraint{Capacity: DefaultCapacityPolicy()})
+
+	t.Run("user with a free slot is confirmed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetUserByID", ctx, 1).Return(&User{ID: 1, CurrentLoad: 9, MaxCapacity: 10}, nil)
+
+		assert.NoError(t, service.ConfirmCandidate(ctx, task, 1))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("slot taken meanwhile is reported", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetUserByID", ctx, 1).Return(&User{ID: 1, CurrentLoad: 10, MaxCapacity: 10}, nil)
+
+		err := service.ConfirmCandidate(ctx, task, 1)
+
+		assert.ErrorIs(t, err, ErrCandidateUnavailable)
+	})
+
+	t.Run("capacity is not checked unless configured", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, WithConstraints(RequiredSkillsConstraint{}))
+		mockRepo.On("GetUserByID", ctx, 1).
+			Return(&User{ID: 1, Skills: SkillsFromNames("go"), CurrentLoad: 10, MaxCapacity: 10}, nil)
+
+		assert.NoError(t, service.ConfirmCandidate(ctx, Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("go")}, 1))
+	})
+
+	t.Run("configured constraints are checked again", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, WithConstraints(RequiredSkillsConstraint{}))
+		mockRepo.On("GetUserByID", ctx, 1).Return(&User{ID: 1, CurrentLoad: 1, MaxCapacity: 10}, nil)
+
+		err := service.ConfirmCandidate(ctx, Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("go")}, 1)
+
+		assert.ErrorIs(t, err, ErrCandidateUnavailable)
+		assert.Contains(t, err.Error(), "go")
+	})
+
+	t.Run("without constraints the user is not reloaded", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo)
+
+		assert.NoError(t, service.ConfirmCandidate(ctx, task, 1))
+		mockRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed reload is not an unavailable candidate", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetUserByID", ctx, 1).Return(nil, errors.New("connection reset"))
+
+		err := service.ConfirmCandidate(ctx, task, 1)
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCandidateUnavailable)
+	})
+}
+
+func TestExplainCandidate(t *testing.T) {
+	ctx := context.Background()
+
+	users := []User{
+		{ID: 1, Name: "Busy", Skills: SkillsFromNames("php"), CurrentLoad: 10, MaxCapacity: 10},
+		{ID: 2, Name: "Available", Skills: SkillsFromNames("php"), CurrentLoad: 1, MaxCapacity: 10},
+		{ID: 3, Name: "Idle", Skills: SkillsFromNames("php"), CurrentLoad: 0, MaxCapacity: 10},
+	}
+	task := Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("php")}
+	capacity := WithConstraints(CapacityConstraint{Capacity: DefaultCapacityPolicy()})
+
+	t.Run("candidate gets score and rank", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		explanation, err := service.ExplainCandidate(ctx, task, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, explanation.Rank)
+		assert.Equal(t, 2, explanation.Candidates)
+		assert.Equal(t, 2, explanation.Result.UserID)
+		assert.Nil(t, explanation.Exclusion)
+	})
+
+	t.Run("excluded user gets the reason", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		explanation, err := service.ExplainCandidate(ctx, task, 1)
+
+		assert.NoError(t, err)
+		assert.Nil(t, explanation.Result)
+		assert.Equal(t, 0, explanation.Rank)
+		assert.Contains(t, explanation.Exclusion.Reason, "no capacity")
+	})
+
+	t.Run("unknown user is not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewOptimizerService(mockRepo, capacity)
+		mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+		_, err := service.ExplainCandidate(ctx, task, 99)
+
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxMessage is an event waiting in the transactional outbox to be published
+type OutboxMessage struct {
+	ID         int64
+	RoutingKey string
+	Payload    []byte
+	Attempts   int
+	CreatedAt  time.Time
+
+	// TraceContext is the W3C trace context of the operation that queued the message
+	TraceContext map[string]string
+}
+
+// NewTaskAssignedMessage creates the outbox message for a task.assigned event
+func NewTaskAssignedMessage(event TaskAssignedEvent) (OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return OutboxMessage{}, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return OutboxMessage{
+		RoutingKey: EventTaskAssigned,
+		Payload:    payload,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Reasons a published message was not accepted by the broker
+var (
+	ErrMessageUnroutable = errors.New("message unroutable")
+	ErrMessageNacked     = errors.New("message nacked by broker")
+	ErrConfirmTimeout    = errors.New("timed out waiting for publisher confirm")
+)
+
+// PublishError is returned when the broker did not accept a published message.
+// Err is one of ErrMessageUnroutable, ErrMessageNacked or ErrConfirmTimeout.
+type PublishError struct {
+	RoutingKey string
+	Err        error
+	Detail     string
+}
+
+// Error implements error
+func (e *PublishError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("failed to publish %s: %v", e.RoutingKey, e.Err)
+	}
+	return fmt.Sprintf("failed to publish %s: %v: %s", e.RoutingKey, e.Err, e.Detail)
+}
+
+// Unwrap allows errors.Is(err, ErrMessageUnroutable) and the other reasons
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Built-in scoring factor names
+const (
+	FactorSkill    = "skill"
+	FactorLoad     = "load"
+	FactorPriority = "priority"
+)
+
+// Scorer rates how well a user suits a task on a single factor
+type Scorer interface {
+	// Score returns a value in the 0..1 range and a human-readable explanation
+	Score(task Task, user User) (float64, string)
+}
+
+// Preparer is implemented by scorers that need data loaded before a task is scored.
+// Prepare returns a scorer bound to that data, so the shared scorer stays safe for concurrent use.
+type Preparer interface {
+	Prepare(ctx context.Context, task Task, users []User) (Scorer, error)
+}
+
+// FactorWeight is a factor name and its weight as read from configuration
+type FactorWeight struct {
+	Name   string
+	Weight float64
+}
+
+// WeightedFactor binds a named scorer to its weight in the composite score
+type WeightedFactor struct {
+	Name   string
+	Weight float64
+	Scorer Scorer
+}
+
+// FactorScore is the outcome of a single factor for a single user
+type FactorScore struct {
+	Name        string
+	Weight      float64
+	Score       float64
+	Explanation string
+}
+
+// DefaultFactorWeights returns the weights of the original scoring formula
+func DefaultFactorWeights() []FactorWeight {
+	return []FactorWeight{
+		{Name: FactorSkill, Weight: 0.4},
+		{Name: FactorLoad, Weight: 0.4},
+		{Name: FactorPriority, Weight: 0.2},
+	}
+}
+
+// CompositeScorer combines several factors into a weighted total
+type CompositeScorer struct {
+	factors []WeightedFactor
+}
+
+// NewCompositeScorer creates a composite scorer from weighted factors
+func NewCompositeScorer(factors ...WeightedFactor) (*CompositeScorer, error) {
+	if len(factors) == 0 {
+		return nil, fmt.Errorf("at least one scoring factor is required")
+	}
+
+	total := 0.0
+	for _, f := range factors {
+		if f.Scorer == nil {
+			return nil, fmt.Errorf("scoring factor %q has no scorer", f.Name)
+		}
+		if f.Weight < 0 {
+			return nil, fmt.Errorf("scoring factor %q has negative weight: %v", f.Name, f.Weight)
+		}
+		total += f.Weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("total weight of scoring factors must be positive")
+	}
+
+	return &CompositeScorer{factors: factors}, nil
+}
+
+// NewCompositeScorerFromWeights builds a composite scorer from built-in factors
+func NewCompositeScorerFromWeights(weights []FactorWeight) (*CompositeScorer, error) {
+	return DefaultFactorRegistry().Build(weights)
+}
+
+// FactorRegistry maps factor names to their scorer implementations
+type FactorRegistry map[string]Scorer
+
+// DefaultFactorRegistry returns the built-in factors with default settings
+func DefaultFactorRegistry() FactorRegistry {
+	return FactorRegistry{
+		FactorSkill:    SkillScorer{},
+		FactorLoad:     LoadScorer{},
+		FactorPriority: PriorityScorer{},
+		FactorDeadline: NewDeadlineScorer(DefaultCapacityPolicy()),
+	}
+}
+
+// Build creates a composite scorer from the registered factors named in weights
+func (r FactorRegistry) Build(weights []FactorWeight) (*CompositeScorer, error) {
+	factors := make([]WeightedFactor, 0, len(weights))
+
+	for _, w := range weights {
+		scorer, ok := r[w.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown scoring factor: %q", w.Name)
+		}
+
+		factors = append(factors, WeightedFactor{
+			Name:   w.Name,
+			Weight: w.Weight,
+			Scorer: scorer,
+		})
+	}
+
+	return NewCompositeScorer(factors...)
+}
+
+// Prepare returns a composite whose factors are bound to the data needed to score task against users.
+// When no factor implements Preparer the scorer itself is returned.
+func (c *CompositeScorer) Prepare(ctx context.Context, task Task, users []User) (*CompositeScorer, error) {
+	var prepared []WeightedFactor
+
+	for i, f := range c.factors {
+		preparer, ok := f.Scorer.(Preparer)
+		if !ok {
+			continue
+		}
+
+		scorer, err := preparer.Prepare(ctx, task, users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare %s factor: %w", f.Name, err)
+		}
+
+		if prepared == nil {
+			prepared = append([]WeightedFactor(nil), c.factors...)
+		}
+		prepared[i].Scorer = scorer
+	}
+
+	if prepared == nil {
+		return c, nil
+	}
+
+	return &CompositeScorer{factors: prepared}, nil
+}
+
+// Evaluate returns the weighted total score and the per-factor breakdown
+func (c *CompositeScorer) Evaluate(task Task, user User) (float64, []FactorScore) {
+	breakdown := make([]FactorScore, 0, len(c.factors))
+	weighted := 0.0
+	totalWeight := 0.0
+
+	for _, f := range c.factors {
+		score, explanation := f.Scorer.Score(task, user)
+		score = clamp01(score)
+
+		weighted += score * f.Weight
+		totalWeight += f.Weight
+
+		breakdown = append(breakdown, FactorScore{
+			Name:        f.Name,
+			Weight:      f.Weight,
+			Score:       score,
+			Explanation: explanation,
+		})
+	}
+
+	return weighted / totalWeight, breakdown
+}
+
+// Score implements Scorer so composites can be nested
+func (c *CompositeScorer) Score(task Task, user User) (float64, string) {
+	total, breakdown := c.Evaluate(task, user)
+	return total, explainFactors(breakdown)
+}
+
+// SkillScorer scores the share of required task skills the user has
+type SkillScorer struct {
+	Normalizer *SkillNormalizer
+}
+
+// Score implements Scorer
+func (s SkillScorer) Score(task Task, user User) (float64, string) {
+	score := matchSkills(s.Normalizer, user.Skills, task.Skills)
+	return score, fmt.Sprintf("Skill match: %.0f%%", score*100)
+}
+
+// LoadScorer scores the free capacity of the user.
+// A user the incoming task does not fit into gets a zero score.
+type LoadScorer struct {
+	Capacity CapacityPolicy
+}
+
+// Score implements Scorer
+func (s LoadScorer) Score(task Task, user User) (float64, string) {
+	unit := s.Capacity.Unit()
+	explanation := fmt.Sprintf("Load: %d/%d%s", user.CurrentLoad, user.MaxCapacity, unit)
+
+	if !s.Capacity.Fits(user, task) {
+		return 0.0, explanation
+	}
+
+	return calculateLoadScore(user.CurrentLoad, user.MaxCapacity), explanation
+}
+
+// PriorityScorer gives higher priority tasks a bonus
+type PriorityScorer struct{}
+
+// Score implements Scorer
+func (PriorityScorer) Score(task Task, _ User) (float64, string) {
+	return calculatePriorityBonus(task.Priority), fmt.Sprintf("Priority: %d", task.Priority)
+}
+
+func explainFactors(breakdown []FactorScore) string {
+	parts := make([]string, 0, len(breakdown))
+	for _, f := range breakdown {
+		if f.Explanation != "" {
+			parts = append(parts, f.Explanation)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func factorValue(breakdown []FactorScore, name string) float64 {
+	for _, f := range breakdown {
+		if f.Name == name {
+			return f.Score
+		}
+	}
+	return 0.0
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedScorer always returns the same score
+type fixedScorer struct {
+	score       float64
+	explanation string
+}
+
+func (f fixedScorer) Score(_ Task, _ User) (float64, string) {
+	return f.score, f.explanation
+}
+
+func TestNewCompositeScorerFromWeights(t *testing.T) {
+	t.Run("default weights reproduce original formula", func(t *testing.T) {
+		scorer, err := NewCompositeScorerFromWeights(DefaultFactorWeights())
+		require.NoError(t, err)
+
+		user := User{Skills: SkillsFromNames("php"), CurrentLoad: 5, MaxCapacity: 10}
+		task := Task{Priority: 5, Skills: RequirementsFromNames("php", "laravel")}
+
+		total, breakdown := scorer.Evaluate(task, user)
+
+		assert.InDelta(t, 0.5*0.4+0.5*0.4+1.0*0.2, total, 1e-9)
+		assert.Len(t, breakdown, 3)
+		assert.Equal(t, "Skill match: 50%, Load: 5/10, Priority: 5", explainFactors(breakdown))
+	})
+
+	t.Run("unknown factor", func(t *testing.T) {
+		_, err := NewCompositeScorerFromWeights([]FactorWeight{{Name: "karma", Weight: 1}})
+		assert.Error(t, err)
+	})
+
+	t.Run("zero total weight", func(t *testing.T) {
+		_, err := NewCompositeScorerFromWeights([]FactorWeight{{Name: FactorSkill, Weight: 0}})
+		assert.Error(t, err)
+	})
+
+	t.Run("negative weight", func(t *testing.T) {
+		_, err := NewCompositeScorerFromWeights([]FactorWeight{{Name: FactorSkill, Weight: -1}})
+		assert.Error(t, err)
+	})
+}
+
+func TestCompositeScorerNormalizesWeights(t *testing.T) {
+	scorer, err := NewCompositeScorer(
+		WeightedFactor{Name: "a", Weight: 3, Scorer: fixedScorer{score: 1.0, explanation: "a"}},
+		WeightedFactor{Name: "b", Weight: 1, Scorer: fixedScorer{score: 0.0, explanation: "b"}},
+	)
+	require.NoError(t, err)
+
+	total, explanation := scorer.Score(Task{}, User{})
+
+	assert.InDelta(t, 0.75, total, 1e-9)
+	assert.Equal(t, "a, b", explanation)
+}
+
+func TestCompositeScorerClampsFactorScores(t *testing.T) {
+	scorer, err := NewCompositeScorer(
+		WeightedFactor{Name: "high", Weight: 1, Scorer: fixedScorer{score: 3.0}},
+		WeightedFactor{Name: "low", Weight: 1, Scorer: fixedScorer{score: -2.0}},
+	)
+	require.NoError(t, err)
+
+	total, breakdown := scorer.Evaluate(Task{}, User{})
+
+	assert.InDelta(t, 0.5, total, 1e-9)
+	assert.Equal(t, 1.0, breakdown[0].Score)
+	assert.Equal(t, 0.0, breakdown[1].Score)
+}
+
+func TestFindBestAssigneeWithCustomScorer(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockUserRepository)
+
+	// Load-only scoring ignores skills completely
+	scorer, err := NewCompositeScorerFromWeights([]FactorWeight{{Name: FactorLoad, Weight: 1}})
+	require.NoError(t, err)
+
+	service := NewOptimizerService(mockRepo, WithScorer(scorer))
+
+	users := []User{
+		{ID: 1, Name: "Expert", Skills: SkillsFromNames("go"), CurrentLoad: 6, MaxCapacity: 10},
+		{ID: 2, Name: "Idle", Skills: SkillsFromNames(), CurrentLoad: 0, MaxCapacity: 10},
+	}
+	mockRepo.On("GetActiveUsers", ctx).Return(users, nil)
+
+	result, err := service.FindBestAssignee(ctx, Task{ID: 1, Priority: 3, Skills: RequirementsFromNames("go")})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.UserID)
+	assert.Equal(t, 1.0, result.TotalScore)
+	assert.Equal(t, "Load: 0/10", result.Reason)
+	assert.Equal(t, 0.0, result.SkillScore, "disabled factor is not reported")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,122 @@
+package domain
+
+import "strings"
+
+// SkillDefinition is an entry of the Laravel skills catalog
+type SkillDefinition struct {
+	Name     string
+	Category string
+	IsActive bool
+}
+
+// SkillNormalizer turns skill names into a canonical form so "Go", "golang"
+// and "go-lang" all match, and knows which skills share a category.
+// A nil normalizer only compares names case-insensitively.
+type SkillNormalizer struct {
+	canonical     map[string]string
+	categories    map[string]string
+	relatedCredit float64
+}
+
+// NewSkillNormalizer creates a normalizer from the skills catalog and an alias map.
+// Aliases map alternative spellings to canonical names. relatedCredit is the share
+// of credit given for a different skill of the same category, 0 disables it.
+func NewSkillNormalizer(catalog []SkillDefinition, aliases map[string]string, relatedCredit float64) *SkillNormalizer {
+	n := &SkillNormalizer{
+		canonical:     make(map[string]string),
+		categories:    make(map[string]string),
+		relatedCredit: clamp01(relatedCredit),
+	}
+
+	for _, skill := range catalog {
+		name := strings.ToLower(strings.TrimSpace(skill.Name))
+		if name == "" {
+			continue
+		}
+
+		n.canonical[skillKey(name)] = name
+		if skill.IsActive && skill.Category != "" {
+			n.categories[name] = skill.Category
+		}
+	}
+
+	for alias, target := range aliases {
+		n.canonical[skillKey(alias)] = n.Canonical(target)
+	}
+
+	return n
+}
+
+// Canonical returns the canonical form of a skill name
+func (n *SkillNormalizer) Canonical(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if n == nil {
+		return name
+	}
+
+	if canonical, ok := n.canonical[skillKey(name)]; ok {
+		return canonical
+	}
+	return name
+}
+
+// Related reports whether two different skills belong to the same active category
+func (n *SkillNormalizer) Related(a, b string) bool {
+	if n == nil || n.relatedCredit == 0 {
+		return false
+	}
+
+	categoryA, okA := n.categories[n.Canonical(a)]
+	categoryB, okB := n.categories[n.Canonical(b)]
+	return okA && okB && categoryA == categoryB
+}
+
+// RelatedCredit returns the share of credit given for related skills
+func (n *SkillNormalizer) RelatedCredit() float64 {
+	if n == nil {
+		return 0
+	}
+	return n.relatedCredit
+}
+
+// Same reports whether two names refer to the same skill
+func (n *SkillNormalizer) Same(a, b string) bool {
+	return skillKey(n.Canonical(a)) == skillKey(n.Canonical(b))
+}
+
+// find returns the user skill matching the name
+func (n *SkillNormalizer) find(skills SkillSet, name string) (Skill, bool) {
+	for _, skill := range skills {
+		if n.Same(skill.Name, name) {
+			return skill, true
+		}
+	}
+	return Skill{}, false
+}
+
+// match returns the credit a user gets for a requirement, from 0 to 1.
+// A different skill of the same category earns the related credit share.
+func (n *SkillNormalizer) match(skills SkillSet, requirement SkillRequirement) float64 {
+	if skill, ok := n.find(skills, requirement.Name); ok {
+		return requirement.credit(skill.Level)
+	}
+
+	best := 0.0
+	for _, skill := range skills {
+		if n.Related(skill.Name, requirement.Name) {
+			best = max(best, n.RelatedCredit()*requirement.credit(skill.Level))
+		}
+	}
+	return best
+}
+
+// skillKey strips separators so "go-lang", "go_lang" and "go lang" share a key
+func skillKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNormalizer(relatedCredit float64) *SkillNormalizer {
+	catalog := []SkillDefinition{
+		{Name: "Go", Category: "technical", IsActive: true},
+		{Name: "PHP", Category: "technical", IsActive: true},
+		{Name: "Node.js", Category: "technical", IsActive: true},
+		{Name: "Docker", Category: "tool", IsActive: true},
+		{Name: "Kubernetes", Category: "tool", IsActive: false},
+		{Name: "English", Category: "language", IsActive: true},
+	}
+	aliases := map[string]string{
+		"golang": "go",
+		"k8s":    "kubernetes",
+	}
+	return NewSkillNormalizer(catalog, aliases, relatedCredit)
+}
+
+func TestSkillNormalizerCanonical(t *testing.T) {
+	normalizer := newTestNormalizer(0)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Go", "go"},
+		{"golang", "go"},
+		{"Go-Lang", "go"},
+		{" go_lang ", "go"},
+		{"nodejs", "node.js"},
+		{"Node JS", "node.js"},
+		{"K8S", "kubernetes"},
+		{"Rust", "rust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizer.Canonical(tt.input))
+		})
+	}
+}
+
+func TestSkillNormalizerNilIsCaseInsensitive(t *testing.T) {
+	var normalizer *SkillNormalizer
+
+	assert.Equal(t, "go", normalizer.Canonical(" GO "))
+	assert.True(t, normalizer.Same("PHP", "php"))
+	assert.False(t, normalizer.Related("php", "go"))
+}
+
+func TestSkillNormalizerRelated(t *testing.T) {
+	normalizer := newTestNormalizer(0.5)
+
+	assert.True(t, normalizer.Related("golang", "php"), "same category")
+	assert.False(t, normalizer.Related("go", "docker"), "different category")
+	assert.False(t, normalizer.Related("docker", "k8s"), "inactive skill has no category")
+	assert.False(t, normalizer.Related("go", "rust"), "unknown skill")
+
+	assert.False(t, newTestNormalizer(0).Related("go", "php"), "related credit disabled")
+}
+
+func TestSkillScorerWithNormalizer(t *testing.T) {
+	user := User{Skills: SkillSet{{Name: "go", Level: 4}, {Name: "docker", Level: 3}}}
+	task := Task{Skills: RequirementsFromNames("Golang", "go-lang", "PHP")}
+
+	score, _ := SkillScorer{}.Score(task, user)
+	assert.InDelta(t, 0.0, score, 1e-9, "without normalizer aliases do not match")
+
+	score, explanation := SkillScorer{Normalizer: newTestNormalizer(0)}.Score(task, user)
+	assert.InDelta(t, 2.0/3.0, score, 1e-9)
+	assert.Equal(t, "Skill match: 67%", explanation)
+
+	score, _ = SkillScorer{Normalizer: newTestNormalizer(0.5)}.Score(task, user)
+	assert.InDelta(t, 2.5/3.0, score, 1e-9, "go earns half credit for php")
+}
+
+func TestRequiredSkillsConstraintWithNormalizer(t *testing.T) {
+	constraint := RequiredSkillsConstraint{Normalizer: newTestNormalizer(0.5)}
+	user := User{Skills: SkillsFromNames("go")}
+
+	assert.NoError(t, constraint.Check(Task{Skills: RequirementsFromNames("golang")}, user))
+	assert.EqualError(t,
+		constraint.Check(Task{Skills: RequirementsFromNames("php")}, user),
+		"missing skills: php",
+		"related skills do not satisfy hard requirements",
+	)
+}
@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Skill proficiency levels
+const (
+	MinSkillLevel = 1
+	MaxSkillLevel = 5
+
+	// DefaultSkillLevel is assumed for skills listed without a level
+	DefaultSkillLevel = 3
+)
+
+// skillLevelNames maps textual proficiency levels to their numeric value
+var skillLevelNames = map[string]int{
+	"beginner":     1,
+	"novice":       1,
+	"junior":       2,
+	"elementary":   2,
+	"intermediate": 3,
+	"middle":       3,
+	"advanced":     4,
+	"senior":       4,
+	"expert":       5,
+}
+
+// Skill is a user skill with a proficiency level from 1 to 5
+type Skill struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// SkillSet is the list of user skills.
+// In JSON it accepts the legacy ["go", "php"] form, "go: expert" strings,
+// {"name": "go", "level": 5} objects and a {"go": 5} map.
+type SkillSet []Skill
+
+// SkillRequirement is a skill a task needs with its minimum level and importance
+type SkillRequirement struct {
+	Name     string  `json:"name"`
+	MinLevel int     `json:"min_level,omitempty"`
+	Weight   float64 `json:"weight,omitempty"`
+}
+
+// SkillsFromNames creates skills with the default level from plain names
+func SkillsFromNames(names ...string) SkillSet {
+	skills := make(SkillSet, 0, len(names))
+	for _, name := range names {
+		skills = append(skills, Skill{Name: name, Level: DefaultSkillLevel})
+	}
+	return skills
+}
+
+// RequirementsFromNames creates requirements without minimum level from plain names
+func RequirementsFromNames(names ...string) []SkillRequirement {
+	requirements := make([]SkillRequirement, 0, len(names))
+	for _, name := range names {
+		requirements = append(requirements, SkillRequirement{Name: name})
+	}
+	return requirements
+}
+
+// Names returns the skill names
+func (s SkillSet) Names() []string {
+	names := make([]string, 0, len(s))
+	for _, skill := range s {
+		names = append(names, skill.Name)
+	}
+	return names
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *SkillSet) UnmarshalJSON(data []byte) error {
+	trimmed := strings.TrimSpace(string(data))
+
+	if trimmed == "null" {
+		*s = SkillSet{}
+		return nil
+	}
+
+	if strings.HasPrefix(trimmed, "{") {
+		var levels map[string]json.RawMessage
+		if err := json.Unmarshal(data, &levels); err != nil {
+			return err
+		}
+
+		skills := make(SkillSet, 0, len(levels))
+		for name, rawLevel := range levels {
+			level, err := parseSkillLevel(rawLevel)
+			if err != nil {
+				return fmt.Errorf("skill %q: %w", name, err)
+			}
+			skills = append(skills, Skill{Name: name, Level: level})
+		}
+
+		// Map iteration order is random, keep the result deterministic
+		sort.Slice(skills, func(i, j int) bool {
+			return skills[i].Name < skills[j].Name
+		})
+		*s = skills
+		return nil
+	}
+
+	var skills []Skill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		return err
+	}
+
+	*s = skills
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Skill) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		name, level, err := parseSkillText(text)
+		if err != nil {
+			return err
+		}
+		if level == 0 {
+			level = DefaultSkillLevel
+		}
+		*s = Skill{Name: name, Level: level}
+		return nil
+	}
+
+	var raw struct {
+		Name  string          `json:"name"`
+		Level json.RawMessage `json:"level"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	level, err := parseSkillLevel(raw.Level)
+	if err != nil {
+		return fmt.Errorf("skill %q: %w", raw.Name, err)
+	}
+
+	*s = Skill{Name: strings.TrimSpace(raw.Name), Level: level}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (r *SkillRequirement) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		name, level, err := parseSkillText(text)
+		if err != nil {
+			return err
+		}
+		*r = SkillRequirement{Name: name, MinLevel: level}
+		return nil
+	}
+
+	var raw struct {
+		Name     string          `json:"name"`
+		MinLevel json.RawMessage `json:"min_level"`
+		Weight   float64         `json:"weight"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	level := 0
+	if len(raw.MinLevel) > 0 {
+		parsed, err := parseSkillLevel(raw.MinLevel)
+		if err != nil {
+			return fmt.Errorf("skill %q: %w", raw.Name, err)
+		}
+		level = parsed
+	}
+
+	if raw.Weight < 0 {
+		return fmt.Errorf("skill %q: negative weight %v", raw.Name, raw.Weight)
+	}
+
+	*r = SkillRequirement{Name: strings.TrimSpace(raw.Name), MinLevel: level, Weight: raw.Weight}
+	return nil
+}
+
+// EffectiveWeight returns the importance of the requirement, 1 when unset
+func (r SkillRequirement) EffectiveWeight() float64 {
+	if r.Weight <= 0 {
+		return 1.0
+	}
+	return r.Weight
+}
+
+// credit returns how well a user level satisfies the requirement, from 0 to 1.
+// Users below the minimum level get partial credit proportional to their level.
+func (r SkillRequirement) credit(level int) float64 {
+	if level <= 0 {
+		return 0.0
+	}
+	if r.MinLevel <= 0 || level >= r.MinLevel {
+		return 1.0
+	}
+	return float64(level) / float64(r.MinLevel)
+}
+
+// parseSkillText parses "go", "go: expert" or "go:4". A missing level is returned as 0.
+func parseSkillText(text string) (string, int, error) {
+	name, rawLevel, found := strings.Cut(text, ":")
+	name = strings.TrimSpace(name)
+
+	if !found {
+		return name, 0, nil
+	}
+
+	level, err := parseLevelText(rawLevel)
+	if err != nil {
+		return "", 0, fmt.Errorf("skill %q: %w", name, err)
+	}
+
+	return name, level, nil
+}
+
+// parseSkillLevel parses a JSON level given either as a number or as text
+func parseSkillLevel(raw json.RawMessage) (int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return DefaultSkillLevel, nil
+	}
+
+	var number float64
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return clampSkillLevel(int(number)), nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return 0, fmt.Errorf("invalid level %s", raw)
+	}
+
+	return parseLevelText(text)
+}
+
+func parseLevelText(text string) (int, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	if level, ok := skillLevelNames[text]; ok {
+		return level, nil
+	}
+
+	level, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("unknown level %q", text)
+	}
+
+	return clampSkillLevel(level), nil
+}
+
+func clampSkillLevel(level int) int {
+	if level < MinSkillLevel {
+		return MinSkillLevel
+	}
+	if level > MaxSkillLevel {
+		return MaxSkillLevel
+	}
+	return level
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillSetUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected SkillSet
+	}{
+		{
+			name:     "legacy string list",
+			payload:  `["php", "laravel"]`,
+			expected: SkillSet{{Name: "php", Level: 3}, {Name: "laravel", Level: 3}},
+		},
+		{
+			name:     "strings with levels",
+			payload:  `["go: beginner", "php: expert", "sql:4"]`,
+			expected: SkillSet{{Name: "go", Level: 1}, {Name: "php", Level: 5}, {Name: "sql", Level: 4}},
+		},
+		{
+			name:     "objects",
+			payload:  `[{"name": "go", "level": 5}, {"name": "php", "level": "junior"}, {"name": "sql"}]`,
+			expected: SkillSet{{Name: "go", Level: 5}, {Name: "php", Level: 2}, {Name: "sql", Level: 3}},
+		},
+		{
+			name:     "map",
+			payload:  `{"php": 2, "go": "advanced"}`,
+			expected: SkillSet{{Name: "go", Level: 4}, {Name: "php", Level: 2}},
+		},
+		{
+			name:     "levels are clamped",
+			payload:  `[{"name": "go", "level": 9}]`,
+			expected: SkillSet{{Name: "go", Level: 5}},
+		},
+		{
+			name:     "null",
+			payload:  `null`,
+			expected: SkillSet{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skills SkillSet
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &skills))
+			assert.Equal(t, tt.expected, skills)
+		})
+	}
+
+	var skills SkillSet
+	assert.Error(t, json.Unmarshal([]byte(`["go: guru"]`), &skills))
+}
+
+func TestSkillRequirementUnmarshalJSON(t *testing.T) {
+	var event TaskCreatedEvent
+	payload := `{"skills": ["php", "go: advanced", {"name": "sql", "min_level": 2, "weight": 0.5}]}`
+
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+
+	assert.Equal(t, []SkillRequirement{
+		{Name: "php"},
+		{Name: "go", MinLevel: 4},
+		{Name: "sql", MinLevel: 2, Weight: 0.5},
+	}, event.ToTask().Skills)
+}
+
+func TestWeightedSkillMatch(t *testing.T) {
+	user := SkillSet{{Name: "go", Level: 2}, {Name: "sql", Level: 5}}
+
+	tests := []struct {
+		name         string
+		requirements []SkillRequirement
+		expected     float64
+	}{
+		{
+			name:         "level meets minimum",
+			requirements: []SkillRequirement{{Name: "sql", MinLevel: 4}},
+			expected:     1.0,
+		},
+		{
+			name:         "partial credit below minimum",
+			requirements: []SkillRequirement{{Name: "go", MinLevel: 4}},
+			expected:     0.5,
+		},
+		{
+			name: "weights favor important skills",
+			requirements: []SkillRequirement{
+				{Name: "sql", Weight: 3},
+				{Name: "k8s", Weight: 1},
+			},
+			expected: 0.75,
+		},
+		{
+			name: "beginner and expert differ",
+			requirements: []SkillRequirement{
+				{Name: "go", MinLevel: 5},
+				{Name: "sql", MinLevel: 5},
+			},
+			expected: (0.4 + 1.0) / 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, calculateSkillMatch(user, tt.requirements), 1e-9)
+		})
+	}
+}
+
+func TestRequiredSkillsConstraintChecksLevel(t *testing.T) {
+	constraint := RequiredSkillsConstraint{}
+	task := Task{Skills: []SkillRequirement{{Name: "go", MinLevel: 4}}}
+
+	assert.NoError(t, constraint.Check(task, User{Skills: SkillSet{{Name: "Go", Level: 5}}}))
+	assert.EqualError(t,
+		constraint.Check(task, User{Skills: SkillSet{{Name: "go", Level: 1}}}),
+		"missing skills: go (level 1 < 4)",
+	)
+}
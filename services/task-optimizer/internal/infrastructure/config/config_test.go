@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEnvList(t *testing.T) {
+	t.Run("unset variable uses the default", func(t *testing.T) {
+		t.Setenv("TEST_ENV_LIST", "")
+		require.NoError(t, os.Unsetenv("TEST_ENV_LIST"))
+
+		assert.Equal(t, []string{"required_skills", "capacity"}, getEnvList("TEST_ENV_LIST", "required_skills,capacity"))
+	})
+
+	t.Run("empty variable is an empty list", func(t *testing.T) {
+		t.Setenv("TEST_ENV_LIST", "")
+
+		assert.Empty(t, getEnvList("TEST_ENV_LIST", "required_skills,capacity"))
+	})
+
+	t.Run("values are trimmed and lowercased", func(t *testing.T) {
+		t.Setenv("TEST_ENV_LIST", " Capacity , ,BLOCKLIST")
+
+		assert.Equal(t, []string{"capacity", "blocklist"}, getEnvList("TEST_ENV_LIST", "required_skills"))
+	})
+}
+
+func TestLoadSkillAliases(t *testing.T) {
+	t.Run("unset variable uses the built-in aliases", func(t *testing.T) {
+		t.Setenv("SKILL_ALIASES", "")
+		require.NoError(t, os.Unsetenv("SKILL_ALIASES"))
+
+		cfg, err := Load()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "go", cfg.Skills.Aliases["golang"])
+	})
+
+	t.Run("empty variable disables the aliases", func(t *testing.T) {
+		t.Setenv("SKILL_ALIASES", "")
+
+		cfg, err := Load()
+
+		assert.NoError(t, err)
+		assert.Empty(t, cfg.Skills.Aliases)
+	})
+}
@@ -0,0 +1,455 @@
+package rabbitmq
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// AMQP 0-9-1 frame types
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+)
+
+// confirmOutcome is how the fake broker answers a publish on a confirm channel
+type confirmOutcome int
+
+const (
+	confirmAck confirmOutcome = iota
+	confirmNack
+	confirmReturn
+	confirmNone
+)
+
+// fakeBroker speaks enough AMQP 0-9-1 on a local listener to exercise the connection,
+// consumer and publisher without RabbitMQ. It records every method the clients call
+// so tests can assert on the declared topology, subscriptions and acknowledgements.
+type fakeBroker struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu      sync.Mutex
+	conns   []*brokerConn
+	calls   map[int][]string
+	confirm func(routingKey string) confirmOutcome
+}
+
+// brokerConn is one client connection to the fake broker
+type brokerConn struct {
+	id   int
+	conn net.Conn
+
+	writeMu  sync.Mutex
+	channels map[uint16]*brokerChannel
+}
+
+// brokerChannel is the state of one channel of a client connection
+type brokerChannel struct {
+	consumers   map[string]string // consumer tag by queue
+	confirming  bool
+	published   uint64
+	delivered   uint64
+	publishing  []string // exchange and routing key of the publish awaiting its content
+	mandatory   bool
+	bodySize    uint64
+	body        []byte
+	hasContents bool
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	b := &fakeBroker{
+		t:        t,
+		listener: listener,
+		calls:    make(map[int][]string),
+		confirm:  func(string) confirmOutcome { return confirmAck },
+	}
+
+	go b.accept()
+	t.Cleanup(func() {
+		listener.Close()
+		b.dropConnections()
+	})
+
+	return b
+}
+
+// url returns the AMQP URL clients dial
+func (b *fakeBroker) url() string {
+	return "amqp://guest:guest@" + b.listener.Addr().String() + "/"
+}
+
+// onPublish sets how publishes on confirm channels are answered
+func (b *fakeBroker) onPublish(confirm func(routingKey string) confirmOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.confirm = confirm
+}
+
+// dropConnections closes every client connection as a crashing broker would
+func (b *fakeBroker) dropConnections() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, c := range b.conns {
+		c.conn.Close()
+	}
+}
+
+// connections returns how many connections clients opened so far
+func (b *fakeBroker) connections() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.conns)
+}
+
+// callsOn returns the methods called on the connection with the given number, counting from one,
+// formatted as the method name followed by the names it refers to, e.g. "queue.declare task.created"
+func (b *fakeBroker) callsOn(conn int) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls[conn]...)
+}
+
+// called reports whether the connection with the given number called the method
+func (b *fakeBroker) called(conn int, call string) bool {
+	for _, c := range b.callsOn(conn) {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// waitFor fails the test unless the connection calls the method within a few seconds
+func (b *fakeBroker) waitFor(conn int, call string) {
+	b.t.Helper()
+	require.Eventually(b.t, func() bool { return b.called(conn, call) }, 5*time.Second, 5*time.Millisecond,
+		"broker never received %q on connection %d, got %v", call, conn, b.callsOn(conn))
+}
+
+// deliver sends a message to the consumer of the queue on the connection with the given number
+func (b *fakeBroker) deliver(conn int, queue, routingKey string, body []byte) {
+	b.t.Helper()
+
+	b.mu.Lock()
+	c := b.conns[conn-1]
+	var (
+		channelID uint16
+		tag       string
+		delivery  uint64
+	)
+	for id, channel := range c.channels {
+		if consumerTag, ok := channel.consumers[queue]; ok {
+			channel.delivered++
+			channelID, tag, delivery = id, consumerTag, channel.delivered
+		}
+	}
+	b.mu.Unlock()
+
+	require.NotEmpty(b.t, tag, "no consumer on queue %s", queue)
+
+	c.method(channelID, 60, 60, amqpArgs{}.shortstr(tag).longlong(delivery).octet(0).shortstr("").shortstr(routingKey))
+	c.content(channelID, body)
+}
+
+func (b *fakeBroker) accept() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		b.mu.Lock()
+		c := &brokerConn{id: len(b.conns) + 1, conn: conn, channels: make(map[uint16]*brokerChannel)}
+		b.conns = append(b.conns, c)
+		b.mu.Unlock()
+
+		go b.serve(c)
+	}
+}
+
+func (b *fakeBroker) record(c *brokerConn, call ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls[c.id] = append(b.calls[c.id], strings.Join(call, " "))
+}
+
+func (b *fakeBroker) serve(c *brokerConn) {
+	defer c.conn.Close()
+
+	r := bufio.NewReader(c.conn)
+	if _, err := io.ReadFull(r, make([]byte, 8)); err != nil {
+		return
+	}
+
+	// connection.start: version 0-9, no server properties, PLAIN authentication
+	c.method(0, 10, 10, amqpArgs{}.octet(0).octet(9).long(0).longstr("PLAIN").longstr("en_US"))
+
+	for {
+		frameType, channelID, payload, err := readFrame(r)
+		if err != nil {
+			return
+		}
+
+		switch frameType {
+		case frameMethod:
+			args := &amqpReader{buf: payload[4:]}
+			if !b.handleMethod(c, channelID, binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:]), args) {
+				return
+			}
+		case frameHeader:
+			b.handleContent(c, channelID, payload, nil)
+		case frameBody:
+			b.handleContent(c, channelID, nil, payload)
+		case frameHeartbeat:
+		}
+	}
+}
+
+// handleMethod answers a method sent by the client and returns false once the connection is closed
+func (b *fakeBroker) handleMethod(c *brokerConn, channelID, class, method uint16, args *amqpReader) bool {
+	b.mu.Lock()
+	channel := c.channels[channelID]
+	b.mu.Unlock()
+
+	switch {
+	case class == 10 && method == 11: // connection.start-ok
+		c.method(0, 10, 30, amqpArgs{}.short(0).long(131072).short(0))
+	case class == 10 && method == 31: // connection.tune-ok
+	case class == 10 && method == 40: // connection.open
+		c.method(0, 10, 41, amqpArgs{}.shortstr(""))
+	case class == 10 && method == 50: // connection.close
+		c.method(0, 10, 51, nil)
+		return false
+	case class == 10 && method == 51: // connection.close-ok
+		return false
+
+	case class == 20 && method == 10: // channel.open
+		b.mu.Lock()
+		c.channels[channelID] = &brokerChannel{consumers: make(map[string]string)}
+		b.mu.Unlock()
+		c.method(channelID, 20, 11, amqpArgs{}.longstr(""))
+	case class == 20 && method == 40: // channel.close
+		b.mu.Lock()
+		delete(c.channels, channelID)
+		b.mu.Unlock()
+		c.method(channelID, 20, 41, nil)
+	case class == 20 && method == 41: // channel.close-ok
+
+	case class == 40 && method == 10: // exchange.declare
+		args.short()
+		name := args.shortstr()
+		args.shortstr()
+		b.record(c, "exchange.declare", name)
+		if args.octet()&(1<<4) == 0 {
+			c.method(channelID, 40, 11, nil)
+		}
+
+	case class == 50 && method == 10: // queue.declare
+		args.short()
+		name := args.shortstr()
+		b.record(c, "queue.declare", name)
+		if args.octet()&(1<<4) == 0 {
+			c.method(channelID, 50, 11, amqpArgs{}.shortstr(name).long(0).long(0))
+		}
+	case class == 50 && method == 20: // queue.bind
+		args.short()
+		queue, exchange, routingKey := args.shortstr(), args.shortstr(), args.shortstr()
+		b.record(c, "queue.bind", queue, exchange, routingKey)
+		if args.octet()&1 == 0 {
+			c.method(channelID, 50, 21, nil)
+		}
+
+	case class == 60 && method == 10: // basic.qos
+		args.long()
+		b.record(c, "basic.qos", fmt.Sprint(args.short()))
+		c.method(channelID, 60, 11, nil)
+	case class == 60 && method == 20: // basic.consume
+		args.short()
+		queue, tag := args.shortstr(), args.shortstr()
+		b.mu.Lock()
+		channel.consumers[queue] = tag
+		b.mu.Unlock()
+		b.record(c, "basic.consume", queue)
+		if args.octet()&(1<<3) == 0 {
+			c.method(channelID, 60, 21, amqpArgs{}.shortstr(tag))
+		}
+	case class == 60 && method == 30: // basic.cancel
+		tag := args.shortstr()
+		b.mu.Lock()
+		for queue, consumerTag := range channel.consumers {
+			if consumerTag == tag {
+				delete(channel.consumers, queue)
+				b.calls[c.id] = append(b.calls[c.id], "basic.cancel "+queue)
+			}
+		}
+		b.mu.Unlock()
+		if args.octet()&1 == 0 {
+			c.method(channelID, 60, 31, amqpArgs{}.shortstr(tag))
+		}
+	case class == 60 && method == 40: // basic.publish, completed by its content frames
+		args.short()
+		b.mu.Lock()
+		channel.publishing = []string{args.shortstr(), args.shortstr()}
+		channel.mandatory = args.octet()&1 != 0
+		b.mu.Unlock()
+	case class == 60 && method == 80: // basic.ack
+		b.record(c, "basic.ack", fmt.Sprint(args.longlong()))
+	case class == 60 && method == 90: // basic.reject
+		b.record(c, "basic.reject", fmt.Sprint(args.longlong()))
+	case class == 60 && method == 120: // basic.nack
+		b.record(c, "basic.nack", fmt.Sprint(args.longlong()))
+
+	case class == 85 && method == 10: // confirm.select
+		b.mu.Lock()
+		channel.confirming = true
+		b.mu.Unlock()
+		b.record(c, "confirm.select")
+		if args.octet()&1 == 0 {
+			c.method(channelID, 85, 11, nil)
+		}
+
+	default:
+		b.t.Errorf("fake broker does not support method %d.%d", class, method)
+		return false
+	}
+
+	return true
+}
+
+// handleContent collects the content of a publish and answers it once complete
+func (b *fakeBroker) handleContent(c *brokerConn, channelID uint16, header, body []byte) {
+	b.mu.Lock()
+	channel := c.channels[channelID]
+	if header != nil {
+		channel.bodySize = binary.BigEndian.Uint64(header[4:12])
+		channel.body = nil
+		channel.hasContents = true
+	}
+	channel.body = append(channel.body, body...)
+	if !channel.hasContents || uint64(len(channel.body)) < channel.bodySize {
+		b.mu.Unlock()
+		return
+	}
+
+	exchange, routingKey := channel.publishing[0], channel.publishing[1]
+	payload := channel.body
+	channel.hasContents = false
+	b.calls[c.id] = append(b.calls[c.id], "basic.publish "+routingKey)
+
+	if !channel.confirming {
+		b.mu.Unlock()
+		return
+	}
+	channel.published++
+	tag := channel.published
+	outcome := b.confirm(routingKey)
+	if outcome == confirmReturn && !channel.mandatory {
+		// The broker drops an unroutable message that is not mandatory
+		outcome = confirmAck
+	}
+	b.mu.Unlock()
+
+	switch outcome {
+	case confirmAck:
+		c.method(channelID, 60, 80, amqpArgs{}.longlong(tag).octet(0))
+	case confirmNack:
+		c.method(channelID, 60, 120, amqpArgs{}.longlong(tag).octet(0))
+	case confirmReturn:
+		// An unroutable mandatory message comes back before its confirm
+		c.method(channelID, 60, 50, amqpArgs{}.short(312).shortstr("NO_ROUTE").shortstr(exchange).shortstr(routingKey))
+		c.content(channelID, payload)
+		c.method(channelID, 60, 80, amqpArgs{}.longlong(tag).octet(0))
+	case confirmNone:
+	}
+}
+
+// method sends a method frame
+func (c *brokerConn) method(channelID, class, method uint16, args amqpArgs) {
+	payload := binary.BigEndian.AppendUint16(nil, class)
+	payload = binary.BigEndian.AppendUint16(payload, method)
+	c.write(frameMethod, channelID, append(payload, args...))
+}
+
+// content sends the header and body frames of a message without properties
+func (c *brokerConn) content(channelID uint16, body []byte) {
+	c.write(frameHeader, channelID, amqpArgs{}.short(60).short(0).longlong(uint64(len(body))).short(0))
+	c.write(frameBody, channelID, body)
+}
+
+func (c *brokerConn) write(frameType byte, channelID uint16, payload []byte) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	frame := []byte{frameType}
+	frame = binary.BigEndian.AppendUint16(frame, channelID)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(payload)))
+	frame = append(frame, payload...)
+	_, _ = c.conn.Write(append(frame, frameEnd))
+}
+
+func readFrame(r *bufio.Reader) (frameType byte, channelID uint16, payload []byte, err error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, err
+	}
+
+	payload = make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+
+	return header[0], binary.BigEndian.Uint16(header[1:3]), payload[:len(payload)-1], nil
+}
+
+// amqpArgs encodes method arguments
+type amqpArgs []byte
+
+func (a amqpArgs) octet(v byte) amqpArgs { return append(a, v) }
+
+func (a amqpArgs) short(v uint16) amqpArgs { return binary.BigEndian.AppendUint16(a, v) }
+
+func (a amqpArgs) long(v uint32) amqpArgs { return binary.BigEndian.AppendUint32(a, v) }
+
+func (a amqpArgs) longlong(v uint64) amqpArgs { return binary.BigEndian.AppendUint64(a, v) }
+
+func (a amqpArgs) shortstr(s string) amqpArgs { return append(a.octet(byte(len(s))), s...) }
+
+func (a amqpArgs) longstr(s string) amqpArgs { return append(a.long(uint32(len(s))), s...) }
+
+// amqpReader decodes method arguments
+type amqpReader struct {
+	buf []byte
+}
+
+func (r *amqpReader) next(n int) []byte {
+	value := r.buf[:n]
+	r.buf = r.buf[n:]
+	return value
+}
+
+func (r *amqpReader) octet() byte { return r.next(1)[0] }
+
+func (r *amqpReader) short() uint16 { return binary.BigEndian.Uint16(r.next(2)) }
+
+func (r *amqpReader) long() uint32 { return binary.BigEndian.Uint32(r.next(4)) }
+
+func (r *amqpReader) longlong() uint64 { return binary.BigEndian.Uint64(r.next(8)) }
+
+func (r *amqpReader) shortstr() string { return string(r.next(int(r.octet()))) }
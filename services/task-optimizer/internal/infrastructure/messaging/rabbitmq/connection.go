@@ -1,49 +1,203 @@
 package rabbitmq
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.uber.org/zap"
 )
 
-// Connection manages RabbitMQ connection and channel
+// Backoff between reconnection attempts
+const (
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 30 * time.Second
+)
+
+// ErrNotConnected is returned while the connection is down and being re-established
+var ErrNotConnected = errors.New("not connected to RabbitMQ")
+
+// Connection manages a supervised RabbitMQ connection and channel.
+// When the broker closes either of them the connection is re-dialled with backoff,
+// every exchange and queue declared so far is declared again and consumers waiting
+// on Reconnected resume.
 type Connection struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
-	logger  *zap.Logger
+	url    string
+	logger *zap.Logger
+
+	mu          sync.RWMutex
+	conn        *amqp.Connection
+	channel     *amqp.Channel
+	ready       bool
+	reconnected chan struct{}
+	topology    []func(*amqp.Channel) error
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-// NewConnection creates a new RabbitMQ connection
+// NewConnection creates a new RabbitMQ connection and starts supervising it
 func NewConnection(url string, logger *zap.Logger) (*Connection, error) {
-	conn, err := amqp.Dial(url)
+	c := &Connection{
+		url:         url,
+		logger:      logger,
+		reconnected: make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Connected to RabbitMQ successfully")
+
+	go c.supervise()
+
+	return c, nil
+}
+
+// connect dials the broker, opens the channel and declares the recorded topology
+func (c *Connection) connect() error {
+	conn, err := amqp.Dial(c.url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	channel, err := conn.Channel()
 	if err != nil {
 		conn.Close()
-		return nil, fmt.Errorf("failed to open channel: %w", err)
+		return fmt.Errorf("failed to open channel: %w", err)
 	}
 
-	logger.Info("Connected to RabbitMQ successfully")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, declare := range c.topology {
+		if err := declare(channel); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to declare topology: %w", err)
+		}
+	}
+
+	c.conn = conn
+	c.channel = channel
+	c.ready = true
+
+	return nil
+}
+
+// supervise waits for the connection or channel to close and re-establishes them
+func (c *Connection) supervise() {
+	for {
+		c.mu.RLock()
+		connClosed := c.conn.NotifyClose(make(chan *amqp.Error, 1))
+		channelClosed := c.channel.NotifyClose(make(chan *amqp.Error, 1))
+		c.mu.RUnlock()
+
+		var reason *amqp.Error
+		select {
+		case <-c.done:
+			return
+		case reason = <-connClosed:
+		case reason = <-channelClosed:
+		}
 
-	return &Connection{
-		conn:    conn,
-		channel: channel,
-		logger:  logger,
-	}, nil
+		c.mu.Lock()
+		c.ready = false
+		conn := c.conn
+		c.mu.Unlock()
+
+		c.logger.Warn("RabbitMQ connection lost, reconnecting", zap.Any("reason", reason))
+
+		// A closed channel leaves the connection open, drop it so both are rebuilt
+		if !conn.IsClosed() {
+			_ = conn.Close()
+		}
+
+		if !c.reconnect() {
+			return
+		}
+	}
+}
+
+// reconnect dials until it succeeds or the connection is closed, doubling the delay between attempts
+func (c *Connection) reconnect() bool {
+	delay := reconnectMinDelay
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-c.done:
+			return false
+		case <-time.After(delay):
+		}
+
+		if err := c.connect(); err != nil {
+			c.logger.Warn("Failed to reconnect to RabbitMQ",
+				zap.Int("attempt", attempt),
+				zap.Duration("next_delay", min(2*delay, reconnectMaxDelay)),
+				zap.Error(err),
+			)
+			delay = min(2*delay, reconnectMaxDelay)
+			continue
+		}
+
+		c.mu.Lock()
+		close(c.reconnected)
+		c.reconnected = make(chan struct{})
+		c.mu.Unlock()
+
+		c.logger.Info("Reconnected to RabbitMQ", zap.Int("attempt", attempt))
+		return true
+	}
+}
+
+// IsReady reports whether the connection is currently up
+func (c *Connection) IsReady() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ready
 }
 
-// Close closes the connection and channel
+// Check returns an error unless the connection and its channel are open
+func (c *Connection) Check(_ context.Context) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch {
+	case !c.ready:
+		return ErrNotConnected
+	case c.conn.IsClosed():
+		return errors.New("connection is closed")
+	case c.channel.IsClosed():
+		return errors.New("channel is closed")
+	}
+	return nil
+}
+
+// Reconnected returns a channel that is closed once the connection has been re-established
+func (c *Connection) Reconnected() <-chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.reconnected
+}
+
+// Close stops supervision and closes the connection and channel
 func (c *Connection) Close() error {
-	if c.channel != nil {
+	c.closeOnce.Do(func() { close(c.done) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ready = false
+
+	if c.channel != nil && !c.channel.IsClosed() {
 		if err := c.channel.Close(); err != nil {
 			c.logger.Error("Failed to close channel", zap.Error(err))
 		}
 	}
-	if c.conn != nil {
+	if c.conn != nil && !c.conn.IsClosed() {
 		if err := c.conn.Close(); err != nil {
 			c.logger.Error("Failed to close connection", zap.Error(err))
 			return err
@@ -53,54 +207,194 @@ func (c *Connection) Close() error {
 	return nil
 }
 
+// declare runs a declaration or channel setting on the current channel and records it for reconnects
+func (c *Connection) declare(declaration func(*amqp.Channel) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.ready {
+		return ErrNotConnected
+	}
+
+	if err := declaration(c.channel); err != nil {
+		return err
+	}
+
+	c.topology = append(c.topology, declaration)
+	return nil
+}
+
+// SetPrefetch limits how many unacknowledged deliveries each consumer on the channel receives
+func (c *Connection) SetPrefetch(count int) error {
+	err := c.declare(func(channel *amqp.Channel) error {
+		return channel.Qos(count, 0, false)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	c.logger.Info("Channel prefetch set", zap.Int("prefetch", count))
+	return nil
+}
+
 // DeclareExchange declares an exchange
 func (c *Connection) DeclareExchange(name string) error {
-	return c.channel.ExchangeDeclare(
-		name,
-		"topic",
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+	return c.declare(func(channel *amqp.Channel) error {
+		return channel.ExchangeDeclare(
+			name,
+			"topic",
+			true,
+			false,
+			false,
+			false,
+			nil,
+		)
+	})
 }
 
-// DeclareQueue declares a queue and binds it to an exchange
-func (c *Connection) DeclareQueue(queueName, exchangeName, routingKey string) error {
-	queue, err := c.channel.QueueDeclare(
-		queueName,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+// DeclareQueue declares a queue and binds it to an exchange with every routing key
+func (c *Connection) DeclareQueue(queueName, exchangeName string, routingKeys ...string) error {
+	err := c.declare(func(channel *amqp.Channel) error {
+		queue, err := channel.QueueDeclare(
+			queueName,
+			true,
+			false,
+			false,
+			false,
+			nil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare queue: %w", err)
+		}
+
+		for _, routingKey := range routingKeys {
+			err = channel.QueueBind(
+				queue.Name,
+				routingKey,
+				exchangeName,
+				false,
+				nil,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to bind queue: %w", err)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to declare queue: %w", err)
+		return err
 	}
 
-	err = c.channel.QueueBind(
-		queue.Name,
-		routingKey,
-		exchangeName,
-		false,
-		nil,
+	c.logger.Info("Queue declared and bound",
+		zap.String("queue", queueName),
+		zap.String("exchange", exchangeName),
+		zap.Strings("routing_keys", routingKeys),
 	)
+
+	return nil
+}
+
+// DeclareRetryTopology declares the retry and parking queues of a consumed queue.
+// Each retry delay gets its own queue whose TTL dead-letters messages back into the
+// consumed queue through the dead-letter exchange. Messages that exhausted their retries
+// or failed permanently are kept in the parking queue for inspection.
+func (c *Connection) DeclareRetryTopology(queueName, deadLetterExchange string, policy RetryPolicy) error {
+	retryQueues := make([]string, 0, policy.Retries())
+	for retry := 0; retry < policy.Retries(); retry++ {
+		retryQueues = append(retryQueues, retryQueueName(queueName, policy.Delay(retry)))
+	}
+
+	err := c.declare(func(channel *amqp.Channel) error {
+		err := channel.ExchangeDeclare(
+			deadLetterExchange,
+			"direct",
+			true,
+			false,
+			false,
+			false,
+			nil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+		}
+
+		if err := channel.QueueBind(queueName, queueName, deadLetterExchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue to dead-letter exchange: %w", err)
+		}
+
+		for retry, name := range retryQueues {
+			_, err := channel.QueueDeclare(
+				name,
+				true,
+				false,
+				false,
+				false,
+				amqp.Table{
+					"x-message-ttl":             policy.Delay(retry).Milliseconds(),
+					"x-dead-letter-exchange":    deadLetterExchange,
+					"x-dead-letter-routing-key": queueName,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to declare retry queue: %w", err)
+			}
+		}
+
+		_, err = channel.QueueDeclare(
+			parkingQueueName(queueName),
+			true,
+			false,
+			false,
+			false,
+			nil,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to declare parking queue: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to bind queue: %w", err)
+		return err
 	}
 
-	c.logger.Info("Queue declared and bound",
+	c.logger.Info("Retry topology declared",
 		zap.String("queue", queueName),
-		zap.String("exchange", exchangeName),
-		zap.String("routing_key", routingKey),
+		zap.String("dead_letter_exchange", deadLetterExchange),
+		zap.Strings("retry_queues", retryQueues),
+		zap.String("parking_queue", parkingQueueName(queueName)),
 	)
 
 	return nil
 }
 
-// GetChannel returns the underlying channel
+// OpenConfirmChannel opens a new channel in publisher confirm mode
+func (c *Connection) OpenConfirmChannel() (*amqp.Channel, error) {
+	c.mu.RLock()
+	conn, ready := c.conn, c.ready
+	c.mu.RUnlock()
+
+	if !ready {
+		return nil, ErrNotConnected
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		_ = channel.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	return channel, nil
+}
+
+// GetChannel returns the current channel, which is replaced after a reconnect
 func (c *Connection) GetChannel() *amqp.Channel {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.channel
 }
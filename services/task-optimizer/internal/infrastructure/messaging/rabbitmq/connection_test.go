@@ -0,0 +1,110 @@
+package rabbitmq
+
+import (
+	"context"
+	"task-optimizer/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// waitReconnected fails the test unless the connection is re-established within a few seconds
+func waitReconnected(t *testing.T, reconnected <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection was not re-established")
+	}
+}
+
+func TestConnectionReconnect(t *testing.T) {
+	t.Run("replays the declared topology on the new connection", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.SetPrefetch(5))
+		require.NoError(t, conn.DeclareExchange("tasks"))
+		require.NoError(t, conn.DeclareQueue("task.created", "tasks", "task.created"))
+		require.NoError(t, conn.DeclareRetryTopology("task.created", "tasks.dlx", RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second}))
+		declared := broker.callsOn(1)
+
+		reconnected := conn.Reconnected()
+		broker.dropConnections()
+		waitReconnected(t, reconnected)
+
+		assert.True(t, conn.IsReady())
+		assert.Equal(t, 2, broker.connections())
+		assert.Equal(t, "basic.qos 5", declared[0])
+		assert.Equal(t, declared, broker.callsOn(2))
+	})
+
+	t.Run("declarations fail while disconnected", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		reconnected := conn.Reconnected()
+		broker.dropConnections()
+
+		require.Eventually(t, func() bool { return !conn.IsReady() }, time.Second, time.Millisecond)
+		assert.ErrorIs(t, conn.DeclareExchange("tasks"), ErrNotConnected)
+
+		waitReconnected(t, reconnected)
+		assert.NoError(t, conn.DeclareExchange("tasks"))
+		assert.Equal(t, []string{"exchange.declare tasks"}, broker.callsOn(2))
+	})
+
+	t.Run("closed connection is not re-established", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+
+		require.NoError(t, conn.Close())
+		broker.dropConnections()
+
+		time.Sleep(reconnectMinDelay + 200*time.Millisecond)
+		assert.False(t, conn.IsReady())
+		assert.Equal(t, 1, broker.connections())
+	})
+}
+
+func TestConsumerResumesAfterReconnect(t *testing.T) {
+	broker := newFakeBroker(t)
+	conn, err := NewConnection(broker.url(), zap.NewNop())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	handled := make(chan string, 1)
+	consumer := NewConsumer(conn, RetryPolicy{MaxAttempts: 1}, 1, metrics.Nop{}, zap.NewNop())
+	consumer.Handle("task.created", func(_ context.Context, body []byte) error {
+		handled <- string(body)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, consumer.StartConsuming(ctx, "task.created"))
+	broker.waitFor(1, "basic.consume task.created")
+
+	reconnected := conn.Reconnected()
+	broker.dropConnections()
+	waitReconnected(t, reconnected)
+
+	broker.waitFor(2, "basic.consume task.created")
+	broker.deliver(2, "task.created", "task.created", []byte(`{"task_id":1}`))
+
+	select {
+	case body := <-handled:
+		assert.Equal(t, `{"task_id":1}`, body)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message delivered after the reconnect was not handled")
+	}
+	broker.waitFor(2, "basic.ack 1")
+}
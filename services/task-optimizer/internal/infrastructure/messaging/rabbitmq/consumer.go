@@ -3,37 +3,185 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"task-optimizer/internal/domain"
+	"task-optimizer/pkg/metrics"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// TaskEventHandler defines the handler for task created events
-type TaskEventHandler func(ctx context.Context, event domain.TaskCreatedEvent) error
+// errMalformedMessage marks messages that can never be processed and must not be requeued
+var errMalformedMessage = errors.New("malformed message")
 
-// Consumer handles consuming messages from RabbitMQ
+// MessageHandler handles the raw body of a message
+type MessageHandler func(ctx context.Context, body []byte) error
+
+// JSONHandler adapts a handler of a typed event to a MessageHandler
+func JSONHandler[T any](handler func(ctx context.Context, event T) error) MessageHandler {
+	return func(ctx context.Context, body []byte) error {
+		var event T
+		if err := json.Unmarshal(body, &event); err != nil {
+			return fmt.Errorf("%w: %v", errMalformedMessage, err)
+		}
+		return handler(ctx, event)
+	}
+}
+
+// job is a delivery waiting for a worker
+type job struct {
+	queueName string
+	msg       amqp.Delivery
+}
+
+// Consumer handles consuming messages from RabbitMQ and routes them by routing key.
+// Deliveries of every consumed queue are processed by a shared pool of workers.
+// Failed messages are moved to the retry queues of their queue and parked once the
+// retry policy gives up, see Connection.DeclareRetryTopology.
 type Consumer struct {
-	conn    *Connection
-	logger  *zap.Logger
-	handler TaskEventHandler
+	conn      *Connection
+	retry     RetryPolicy
+	workers   int
+	publisher *Publisher
+	metrics   metrics.Recorder
+	logger    *zap.Logger
+	handlers  map[string]MessageHandler
+
+	jobs        chan job
+	startWorker sync.Once
+	stopOnce    sync.Once
+	workerGroup sync.WaitGroup
+	runGroup    sync.WaitGroup
+
+	mu        sync.Mutex
+	queues    []string
+	consuming map[string]bool
+	stopping  chan struct{}
 }
 
-// NewConsumer creates a new RabbitMQ consumer
-func NewConsumer(conn *Connection, handler TaskEventHandler, logger *zap.Logger) *Consumer {
+// NewConsumer creates a new RabbitMQ consumer processing up to workers messages at a time
+func NewConsumer(conn *Connection, retry RetryPolicy, workers int, recorder metrics.Recorder, logger *zap.Logger) *Consumer {
 	return &Consumer{
-		conn:    conn,
-		logger:  logger,
-		handler: handler,
+		conn:      conn,
+		retry:     retry,
+		workers:   max(workers, 1),
+		publisher: NewPublisher(conn, "", logger),
+		metrics:   recorder,
+		logger:    logger,
+		handlers:  make(map[string]MessageHandler),
+		jobs:      make(chan job),
+		consuming: make(map[string]bool),
+		stopping:  make(chan struct{}),
 	}
 }
 
-// StartConsuming starts consuming messages from the specified queue
+// Handle registers the handler for messages with the routing key
+func (c *Consumer) Handle(routingKey string, handler MessageHandler) {
+	c.handlers[routingKey] = handler
+}
+
+// StartConsuming starts consuming messages from the specified queue.
+// Consumption resumes on the new channel whenever the connection is re-established.
+// Handlers run with ctx, so cancelling it aborts messages in flight; use Shutdown to drain them.
 func (c *Consumer) StartConsuming(ctx context.Context, queueName string) error {
+	c.startWorker.Do(func() {
+		c.workerGroup.Add(c.workers)
+		for i := 0; i < c.workers; i++ {
+			go c.work(ctx)
+		}
+		c.logger.Info("Started consumer workers", zap.Int("workers", c.workers))
+	})
+
+	reconnected := c.conn.Reconnected()
+
+	msgs, err := c.subscribe(queueName)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.queues = append(c.queues, queueName)
+	c.mu.Unlock()
+
+	c.logger.Info("Started consuming messages", zap.String("queue", queueName))
+
+	c.runGroup.Add(1)
+	go c.run(ctx, queueName, msgs, reconnected)
+
+	return nil
+}
+
+// Stop makes the broker stop sending deliveries. The deliveries already received are still
+// processed; Shutdown waits for them. Stop can be called more than once.
+func (c *Consumer) Stop() {
+	c.stopOnce.Do(func() {
+		c.mu.Lock()
+		close(c.stopping)
+		queues := c.queues
+		c.mu.Unlock()
+
+		// Cancelling the consumer tags makes the broker stop sending and closes the delivery
+		// channels once the deliveries already buffered were handed out
+		for _, queueName := range queues {
+			if err := c.conn.GetChannel().Cancel(consumerTag(queueName), false); err != nil {
+				c.logger.Warn("Failed to cancel consumer", zap.String("queue", queueName), zap.Error(err))
+			}
+		}
+	})
+}
+
+// Shutdown stops taking new deliveries and waits until the workers processed the ones
+// already received, or until ctx is done. Deliveries still unacknowledged when the
+// connection closes are redelivered by the broker.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	c.Stop()
+
+	drained := make(chan struct{})
+	go func() {
+		c.runGroup.Wait()
+		close(c.jobs)
+		c.workerGroup.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		c.logger.Info("Consumer drained")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain consumer: %w", ctx.Err())
+	}
+}
+
+// consumerTag identifies the subscription to a queue so it can be cancelled
+func consumerTag(queueName string) string {
+	return "task-optimizer." + queueName
+}
+
+// errStopping is returned when subscribing after Shutdown started
+var errStopping = errors.New("consumer is shutting down")
+
+// subscribe registers the consumer on the current channel
+func (c *Consumer) subscribe(queueName string) (<-chan amqp.Delivery, error) {
+	// Holding the lock keeps Shutdown from cancelling before the subscription exists
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.stopping:
+		return nil, errStopping
+	default:
+	}
+
 	msgs, err := c.conn.GetChannel().Consume(
 		queueName,
-		"",
+		consumerTag(queueName),
 		false,
 		false,
 		false,
@@ -41,64 +189,270 @@ func (c *Consumer) StartConsuming(ctx context.Context, queueName string) error {
 		nil,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to register consumer: %w", err)
+		return nil, fmt.Errorf("failed to register consumer: %w", err)
 	}
+	return msgs, nil
+}
 
-	c.logger.Info("Started consuming messages", zap.String("queue", queueName))
+// run consumes deliveries and subscribes again after every reconnect until the consumer
+// is shut down or the context is cancelled
+func (c *Consumer) run(ctx context.Context, queueName string, msgs <-chan amqp.Delivery, reconnected <-chan struct{}) {
+	defer c.runGroup.Done()
+
+	for {
+		if msgs != nil {
+			c.setConsuming(queueName, true)
+			c.consume(ctx, queueName, msgs)
+			c.setConsuming(queueName, false)
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Consumer stopped", zap.String("queue", queueName))
+			return
+		case <-c.stopping:
+			c.logger.Info("Consumer stopped", zap.String("queue", queueName))
+			return
+		case <-reconnected:
+		}
+
+		reconnected = c.conn.Reconnected()
+
+		var err error
+		msgs, err = c.subscribe(queueName)
+		if errors.Is(err, errStopping) {
+			continue
+		}
+		if err != nil {
+			c.logger.Error("Failed to resume consuming, waiting for next reconnect",
+				zap.String("queue", queueName),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		c.logger.Info("Resumed consuming messages", zap.String("queue", queueName))
+	}
+}
 
-	go c.consume(ctx, msgs)
+// setConsuming records whether deliveries of the queue are being received
+func (c *Consumer) setConsuming(queueName string, consuming bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.consuming[queueName] = consuming
+}
+
+// Check returns an error unless every started queue is being consumed
+func (c *Consumer) Check(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.stopping:
+		return errStopping
+	default:
+	}
 
+	if len(c.queues) == 0 {
+		return errors.New("not consuming any queue")
+	}
+	for _, queueName := range c.queues {
+		if !c.consuming[queueName] {
+			return fmt.Errorf("not consuming queue %s", queueName)
+		}
+	}
 	return nil
 }
 
-func (c *Consumer) consume(ctx context.Context, msgs <-chan amqp.Delivery) {
+// consume hands deliveries to the workers until the context is cancelled or the channel closes
+func (c *Consumer) consume(ctx context.Context, queueName string, msgs <-chan amqp.Delivery) {
 	for {
 		select {
 		case <-ctx.Done():
-			c.logger.Info("Consumer stopped")
 			return
 
 		case msg, ok := <-msgs:
 			if !ok {
-				c.logger.Warn("Message channel closed")
+				select {
+				case <-c.stopping:
+				default:
+					c.logger.Warn("Message channel closed, waiting for reconnect", zap.String("queue", queueName))
+				}
 				return
 			}
 
-			c.processMessage(ctx, msg)
+			select {
+			case c.jobs <- job{queueName: queueName, msg: msg}:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}
 }
 
-func (c *Consumer) processMessage(ctx context.Context, msg amqp.Delivery) {
+// work processes deliveries one at a time until the jobs are drained or the context is cancelled
+func (c *Consumer) work(ctx context.Context) {
+	defer c.workerGroup.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j, ok := <-c.jobs:
+			if !ok {
+				return
+			}
+			c.processMessage(ctx, j.queueName, j.msg)
+		}
+	}
+}
+
+func (c *Consumer) processMessage(ctx context.Context, queueName string, msg amqp.Delivery) {
+	routingKey := originalRoutingKey(msg)
+	retries := retryCount(msg.Headers)
+	c.metrics.MessageConsumed(queueName, routingKey)
+
+	// Continue the trace of the publisher, e.g. the Laravel job that created the task
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(msg.Headers))
+	ctx, span := tracer.Start(ctx, "process "+routingKey,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(queueName),
+			semconv.MessagingRabbitMQDestinationRoutingKey(routingKey),
+			attribute.Int("messaging.rabbitmq.retry_count", retries),
+		),
+	)
+	defer span.End()
+
 	c.logger.Debug("Received message",
-		zap.String("routing_key", msg.RoutingKey),
+		zap.String("routing_key", routingKey),
+		zap.Int("retries", retries),
 		zap.ByteString("body", msg.Body),
 	)
 
-	var event domain.TaskCreatedEvent
-	if err := json.Unmarshal(msg.Body, &event); err != nil {
-		c.logger.Error("Failed to unmarshal message",
-			zap.Error(err),
+	handler, ok := c.handlers[routingKey]
+	if !ok {
+		c.logger.Error("No handler for routing key",
+			zap.String("routing_key", routingKey),
 			zap.ByteString("body", msg.Body),
 		)
-		msg.Nack(false, false)
+		err := fmt.Errorf("no handler for routing key %q", routingKey)
+		recordError(span, err)
+		c.park(ctx, queueName, msg, err)
 		return
 	}
 
-	if err := c.handler(ctx, event); err != nil {
+	if err := handler(ctx, msg.Body); err != nil {
+		recordError(span, err)
+		if errors.Is(err, errMalformedMessage) || errors.Is(err, domain.ErrInvalidEvent) {
+			c.logger.Error("Message failed permanently",
+				zap.Error(err),
+				zap.String("routing_key", routingKey),
+				zap.ByteString("body", msg.Body),
+			)
+			c.park(ctx, queueName, msg, err)
+			return
+		}
+
 		c.logger.Error("Failed to handle event",
 			zap.Error(err),
-			zap.Int("task_id", event.TaskID),
+			zap.String("routing_key", routingKey),
+			zap.Int("retries", retries),
 		)
-		msg.Nack(false, true)
+
+		if retries >= c.retry.Retries() {
+			c.park(ctx, queueName, msg, err)
+			return
+		}
+		c.scheduleRetry(ctx, queueName, msg, retries, err)
 		return
 	}
 
 	if err := msg.Ack(false); err != nil {
 		c.logger.Error("Failed to acknowledge message", zap.Error(err))
 	}
+	c.metrics.MessageAcked(queueName, routingKey)
 
 	c.logger.Info("Message processed successfully",
-		zap.Int("task_id", event.TaskID),
+		zap.String("routing_key", routingKey),
+		zap.Uint64("delivery_tag", msg.DeliveryTag),
+	)
+}
+
+// scheduleRetry moves the message to the retry queue of its next delay
+func (c *Consumer) scheduleRetry(ctx context.Context, queueName string, msg amqp.Delivery, retries int, cause error) {
+	delay := c.retry.Delay(retries)
+
+	if !c.forward(ctx, queueName, retryQueueName(queueName, delay), msg, retries+1, cause) {
+		return
+	}
+	c.metrics.MessageRetried(queueName, originalRoutingKey(msg))
+
+	c.logger.Warn("Message scheduled for retry",
+		zap.String("routing_key", originalRoutingKey(msg)),
+		zap.Int("retry", retries+1),
+		zap.Int("max_retries", c.retry.Retries()),
+		zap.Duration("delay", delay),
+	)
+}
+
+// park moves the message to the parking queue, where it stays until handled manually
+func (c *Consumer) park(ctx context.Context, queueName string, msg amqp.Delivery, cause error) {
+	if !c.forward(ctx, queueName, parkingQueueName(queueName), msg, retryCount(msg.Headers), cause) {
+		return
+	}
+	c.metrics.MessageDeadLettered(queueName, originalRoutingKey(msg))
+
+	c.logger.Error("Message parked",
+		zap.String("routing_key", originalRoutingKey(msg)),
+		zap.String("parking_queue", parkingQueueName(queueName)),
+		zap.Error(cause),
 	)
 }
+
+// forward publishes a copy of the message to a queue and acknowledges the original.
+// When the copy cannot be published the original is requeued so it is not lost.
+func (c *Consumer) forward(
+	ctx context.Context,
+	queueName, targetQueue string,
+	msg amqp.Delivery,
+	retries int,
+	cause error,
+) bool {
+	headers := amqp.Table{}
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[headerRetryCount] = int32(retries)
+	headers[headerOriginalRoutingKey] = originalRoutingKey(msg)
+	headers[headerLastError] = cause.Error()
+
+	err := c.publisher.publish(ctx, "", targetQueue, amqp.Publishing{
+		Headers:       headers,
+		ContentType:   msg.ContentType,
+		CorrelationId: msg.CorrelationId,
+		MessageId:     msg.MessageId,
+		Timestamp:     msg.Timestamp,
+		Body:          msg.Body,
+		DeliveryMode:  amqp.Persistent,
+	})
+	if err != nil {
+		c.logger.Error("Failed to forward message, requeueing",
+			zap.String("queue", targetQueue),
+			zap.Error(err),
+		)
+		if err := msg.Nack(false, true); err != nil {
+			c.logger.Error("Failed to requeue message", zap.Error(err))
+		}
+		c.metrics.MessageNacked(queueName, originalRoutingKey(msg))
+		return false
+	}
+
+	if err := msg.Ack(false); err != nil {
+		c.logger.Error("Failed to acknowledge message", zap.Error(err))
+	}
+
+	return true
+}
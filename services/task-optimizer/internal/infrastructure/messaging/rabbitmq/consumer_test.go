@@ -0,0 +1,149 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"task-optimizer/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConsumerWorkers(t *testing.T) {
+	broker := newFakeBroker(t)
+	conn, err := NewConnection(broker.url(), zap.NewNop())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	consumer := NewConsumer(conn, RetryPolicy{MaxAttempts: 1}, 2, metrics.Nop{}, zap.NewNop())
+	consumer.Handle("task.created", func(context.Context, []byte) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, consumer.StartConsuming(ctx, "task.created"))
+	broker.waitFor(1, "basic.consume task.created")
+
+	for i := 0; i < 3; i++ {
+		broker.deliver(1, "task.created", "task.created", []byte(`{}`))
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("workers did not process messages in parallel")
+		}
+	}
+
+	select {
+	case <-started:
+		t.Fatal("a third message was processed by two workers")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting message was not processed once a worker was free")
+	}
+
+	for tag := 1; tag <= 3; tag++ {
+		broker.waitFor(1, fmt.Sprintf("basic.ack %d", tag))
+	}
+}
+
+func TestConsumerShutdown(t *testing.T) {
+	// startBlocked starts a consumer whose handler holds the first delivery until release is closed
+	startBlocked := func(t *testing.T, broker *fakeBroker, release <-chan struct{}) *Consumer {
+		t.Helper()
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+
+		started := make(chan struct{}, 1)
+		consumer := NewConsumer(conn, RetryPolicy{MaxAttempts: 1}, 1, metrics.Nop{}, zap.NewNop())
+		consumer.Handle("task.created", func(context.Context, []byte) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		require.NoError(t, consumer.StartConsuming(ctx, "task.created"))
+		broker.waitFor(1, "basic.consume task.created")
+
+		broker.deliver(1, "task.created", "task.created", []byte(`{}`))
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not handled")
+		}
+		return consumer
+	}
+
+	t.Run("cancels the subscription and waits for messages in flight", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		release := make(chan struct{})
+		consumer := startBlocked(t, broker, release)
+
+		done := make(chan error, 1)
+		go func() { done <- consumer.Shutdown(context.Background()) }()
+
+		broker.waitFor(1, "basic.cancel task.created")
+		select {
+		case <-done:
+			t.Fatal("shutdown returned before the message in flight was processed")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(release)
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("shutdown did not return once the consumer was drained")
+		}
+		broker.waitFor(1, "basic.ack 1")
+	})
+
+	t.Run("stop cancels the subscription without waiting for messages in flight", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		release := make(chan struct{})
+		consumer := startBlocked(t, broker, release)
+
+		consumer.Stop()
+		consumer.Stop()
+
+		broker.waitFor(1, "basic.cancel task.created")
+		assert.Error(t, consumer.Check(context.Background()), "a stopped consumer is not ready")
+		assert.False(t, broker.called(1, "basic.ack 1"))
+
+		close(release)
+		assert.NoError(t, consumer.Shutdown(context.Background()))
+		broker.waitFor(1, "basic.ack 1")
+	})
+
+	t.Run("gives up when the deadline passes", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		release := make(chan struct{})
+		defer close(release)
+		consumer := startBlocked(t, broker, release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, consumer.Shutdown(ctx), context.DeadlineExceeded)
+		assert.False(t, broker.called(1, "basic.ack 1"))
+	})
+}
@@ -3,18 +3,29 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"task-optimizer/internal/domain"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// Publisher handles publishing messages to RabbitMQ
+// Publisher handles publishing messages to RabbitMQ.
+// It publishes mandatory messages on its own channel in confirm mode, opened on first use,
+// so a message is only reported as published once the broker routed and stored it.
 type Publisher struct {
 	conn         *Connection
 	exchangeName string
 	logger       *zap.Logger
+
+	mu      sync.Mutex
+	channel *amqp.Channel
+	returns chan amqp.Return
 }
 
 // NewPublisher creates a new RabbitMQ publisher
@@ -33,21 +44,8 @@ func (p *Publisher) PublishTaskAssigned(ctx context.Context, event domain.TaskAs
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	err = p.conn.GetChannel().PublishWithContext(
-		ctx,
-		p.exchangeName,
-		"task.assigned",
-		false,
-		false,
-		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
-			DeliveryMode: amqp.Persistent,
-		},
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to publish message: %w", err)
+	if err := p.Publish(ctx, domain.EventTaskAssigned, body); err != nil {
+		return err
 	}
 
 	p.logger.Info("Published task assigned event",
@@ -58,3 +56,109 @@ func (p *Publisher) PublishTaskAssigned(ctx context.Context, event domain.TaskAs
 
 	return nil
 }
+
+// Publish publishes a message and waits until the broker confirms it
+func (p *Publisher) Publish(ctx context.Context, routingKey string, body []byte) error {
+	return p.publish(ctx, p.exchangeName, routingKey, amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		DeliveryMode: amqp.Persistent,
+	})
+}
+
+// publish publishes to any exchange and waits until the broker confirms it.
+// The wait is bounded by the context deadline. The trace context of ctx is sent in the headers.
+func (p *Publisher) publish(ctx context.Context, exchangeName, routingKey string, msg amqp.Publishing) (err error) {
+	ctx, span := tracer.Start(ctx, "publish "+routingKey,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemRabbitMQ,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(exchangeName),
+			semconv.MessagingRabbitMQDestinationRoutingKey(routingKey),
+		),
+	)
+	defer func() {
+		if err != nil {
+			recordError(span, err)
+		}
+		span.End()
+	}()
+
+	if msg.Headers == nil {
+		msg.Headers = amqp.Table{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(msg.Headers))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	channel, err := p.confirmChannel()
+	if err != nil {
+		return err
+	}
+
+	// Drop a return left over from a publish that timed out before its confirm
+	select {
+	case <-p.returns:
+	default:
+	}
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		exchangeName,
+		routingKey,
+		true,
+		false,
+		msg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &domain.PublishError{RoutingKey: routingKey, Err: domain.ErrConfirmTimeout}
+		}
+		return fmt.Errorf("failed to wait for publisher confirm: %w", err)
+	}
+	if !acked {
+		if channel.IsClosed() {
+			return fmt.Errorf("channel closed before publisher confirm: %w", ErrNotConnected)
+		}
+		return &domain.PublishError{RoutingKey: routingKey, Err: domain.ErrMessageNacked}
+	}
+
+	// The broker sends basic.return before the ack of an unroutable mandatory message,
+	// and publishes are serialized, so a pending return belongs to this message
+	select {
+	case returned, ok := <-p.returns:
+		if ok {
+			return &domain.PublishError{
+				RoutingKey: routingKey,
+				Err:        domain.ErrMessageUnroutable,
+				Detail:     fmt.Sprintf("%d %s", returned.ReplyCode, returned.ReplyText),
+			}
+		}
+	default:
+	}
+
+	return nil
+}
+
+// confirmChannel returns the publishing channel, reopening it after it was closed
+func (p *Publisher) confirmChannel() (*amqp.Channel, error) {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return p.channel, nil
+	}
+
+	channel, err := p.conn.OpenConfirmChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	p.channel = channel
+	p.returns = channel.NotifyReturn(make(chan amqp.Return, 1))
+	return channel, nil
+}
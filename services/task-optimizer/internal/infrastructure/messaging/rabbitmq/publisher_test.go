@@ -0,0 +1,97 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"task-optimizer/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPublisherPublish(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome confirmOutcome
+		err     error
+	}{
+		{name: "confirmed message", outcome: confirmAck},
+		{name: "nacked message", outcome: confirmNack, err: domain.ErrMessageNacked},
+		{name: "mandatory message without a bound queue", outcome: confirmReturn, err: domain.ErrMessageUnroutable},
+		{name: "message never confirmed", outcome: confirmNone, err: domain.ErrConfirmTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := newFakeBroker(t)
+			broker.onPublish(func(string) confirmOutcome { return tt.outcome })
+
+			conn, err := NewConnection(broker.url(), zap.NewNop())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			err = NewPublisher(conn, "tasks", zap.NewNop()).Publish(ctx, domain.EventTaskAssigned, []byte(`{"task_id":1}`))
+
+			assert.True(t, broker.called(1, "basic.publish task.assigned"))
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.err)
+			var publishErr *domain.PublishError
+			require.True(t, errors.As(err, &publishErr))
+			assert.Equal(t, domain.EventTaskAssigned, publishErr.RoutingKey)
+		})
+	}
+
+	t.Run("returned message carries the broker reply", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		broker.onPublish(func(string) confirmOutcome { return confirmReturn })
+
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		err = NewPublisher(conn, "tasks", zap.NewNop()).Publish(context.Background(), domain.EventTaskAssigned, []byte(`{}`))
+
+		assert.ErrorContains(t, err, "312 NO_ROUTE")
+	})
+
+	t.Run("a return does not leak into the next publish", func(t *testing.T) {
+		broker := newFakeBroker(t)
+		broker.onPublish(func(routingKey string) confirmOutcome {
+			if routingKey == "task.unbound" {
+				return confirmReturn
+			}
+			return confirmAck
+		})
+
+		conn, err := NewConnection(broker.url(), zap.NewNop())
+		require.NoError(t, err)
+		defer conn.Close()
+		publisher := NewPublisher(conn, "tasks", zap.NewNop())
+
+		ctx := context.Background()
+		assert.ErrorIs(t, publisher.Publish(ctx, "task.unbound", []byte(`{}`)), domain.ErrMessageUnroutable)
+		assert.NoError(t, publisher.Publish(ctx, domain.EventTaskAssigned, []byte(`{}`)))
+		assert.Equal(t, 1, len(filterCalls(broker.callsOn(1), "confirm.select")), "the confirm channel is reused")
+	})
+}
+
+// filterCalls returns the calls equal to the given one
+func filterCalls(calls []string, call string) []string {
+	var matching []string
+	for _, c := range calls {
+		if c == call {
+			matching = append(matching, c)
+		}
+	}
+	return matching
+}
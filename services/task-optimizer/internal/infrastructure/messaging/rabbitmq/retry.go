@@ -0,0 +1,69 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers carried by retried and parked messages
+const (
+	headerRetryCount         = "x-retry-count"
+	headerOriginalRoutingKey = "x-original-routing-key"
+	headerLastError          = "x-last-error"
+)
+
+// RetryPolicy describes how often failed messages are retried before they are parked.
+// The n-th retry waits BaseDelay * 2^n.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// Retries returns how many times a message is retried after its first delivery
+func (p RetryPolicy) Retries() int {
+	return max(p.MaxAttempts-1, 0)
+}
+
+// Delay returns how long the retry with the given zero-based index waits
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	return p.BaseDelay << retry
+}
+
+// retryQueueName returns the name of the queue holding messages of queueName for delay
+func retryQueueName(queueName string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%dms", queueName, delay.Milliseconds())
+}
+
+// parkingQueueName returns the name of the queue keeping messages of queueName that gave up
+func parkingQueueName(queueName string) string {
+	return queueName + ".parking"
+}
+
+// retryCount returns how many times the delivery was retried already
+func retryCount(headers amqp.Table) int {
+	switch count := headers[headerRetryCount].(type) {
+	case int:
+		return count
+	case int8:
+		return int(count)
+	case int16:
+		return int(count)
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	default:
+		return 0
+	}
+}
+
+// originalRoutingKey returns the routing key the message was first published with.
+// Retried messages come back from the dead-letter exchange under the queue name.
+func originalRoutingKey(msg amqp.Delivery) string {
+	if routingKey, ok := msg.Headers[headerOriginalRoutingKey].(string); ok && routingKey != "" {
+		return routingKey
+	}
+	return msg.RoutingKey
+}
@@ -0,0 +1,111 @@
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		retries int
+		delays  []time.Duration
+	}{
+		{
+			name:    "doubles the base delay",
+			policy:  RetryPolicy{MaxAttempts: 4, BaseDelay: time.Second},
+			retries: 3,
+			delays:  []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:    "single attempt never retries",
+			policy:  RetryPolicy{MaxAttempts: 1, BaseDelay: time.Second},
+			retries: 0,
+		},
+		{
+			name:    "no attempts never retries",
+			policy:  RetryPolicy{MaxAttempts: 0, BaseDelay: time.Second},
+			retries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retries, tt.policy.Retries())
+
+			var delays []time.Duration
+			for retry := 0; retry < tt.policy.Retries(); retry++ {
+				delays = append(delays, tt.policy.Delay(retry))
+			}
+			assert.Equal(t, tt.delays, delays)
+		})
+	}
+}
+
+func TestRetryQueueNames(t *testing.T) {
+	assert.Equal(t, "task.created.retry.1500ms", retryQueueName("task.created", 1500*time.Millisecond))
+	assert.Equal(t, "task.created.parking", parkingQueueName("task.created"))
+}
+
+func TestRetryCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  amqp.Table
+		expected int
+	}{
+		{name: "no headers", headers: nil, expected: 0},
+		{name: "header missing", headers: amqp.Table{"other": int32(3)}, expected: 0},
+		{name: "int", headers: amqp.Table{headerRetryCount: 2}, expected: 2},
+		{name: "int8", headers: amqp.Table{headerRetryCount: int8(2)}, expected: 2},
+		{name: "int16", headers: amqp.Table{headerRetryCount: int16(2)}, expected: 2},
+		{name: "int32 as published", headers: amqp.Table{headerRetryCount: int32(2)}, expected: 2},
+		{name: "int64 as decoded from the wire", headers: amqp.Table{headerRetryCount: int64(2)}, expected: 2},
+		{name: "unexpected type", headers: amqp.Table{headerRetryCount: "2"}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, retryCount(tt.headers))
+		})
+	}
+}
+
+func TestOriginalRoutingKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery amqp.Delivery
+		expected string
+	}{
+		{
+			name:     "first delivery keeps its routing key",
+			delivery: amqp.Delivery{RoutingKey: "task.created"},
+			expected: "task.created",
+		},
+		{
+			name: "retried delivery is routed back under the queue name",
+			delivery: amqp.Delivery{
+				RoutingKey: "task.lifecycle",
+				Headers:    amqp.Table{headerOriginalRoutingKey: "task.completed"},
+			},
+			expected: "task.completed",
+		},
+		{
+			name: "empty header falls back to the routing key",
+			delivery: amqp.Delivery{
+				RoutingKey: "task.created",
+				Headers:    amqp.Table{headerOriginalRoutingKey: ""},
+			},
+			expected: "task.created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, originalRoutingKey(tt.delivery))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package rabbitmq
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of consumed and published messages
+var tracer = otel.Tracer("task-optimizer/internal/infrastructure/messaging/rabbitmq")
+
+// headerCarrier carries the W3C trace context in AMQP message headers
+type headerCarrier amqp.Table
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+// Get returns the header value for the key
+func (c headerCarrier) Get(key string) string {
+	switch value := c[key].(type) {
+	case string:
+		return value
+	case []byte:
+		return string(value)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Set stores the header value for the key
+func (c headerCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys lists the header keys
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// recordError marks the span as failed
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,54 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHeaderCarrier(t *testing.T) {
+	propagator := propagation.TraceContext{}
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	published := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	t.Run("round-trips the trace context through the headers", func(t *testing.T) {
+		headers := amqp.Table{"x-retry-count": int32(1)}
+		propagator.Inject(trace.ContextWithSpanContext(context.Background(), published), headerCarrier(headers))
+
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headers["traceparent"])
+		assert.Equal(t, int32(1), headers["x-retry-count"], "other headers are kept")
+
+		consumed := trace.SpanContextFromContext(propagator.Extract(context.Background(), headerCarrier(headers)))
+		assert.True(t, consumed.IsRemote())
+		assert.Equal(t, published.TraceID(), consumed.TraceID())
+		assert.Equal(t, published.SpanID(), consumed.SpanID())
+		assert.True(t, consumed.IsSampled())
+	})
+
+	t.Run("reads a traceparent sent as a byte array", func(t *testing.T) {
+		headers := amqp.Table{"traceparent": []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")}
+
+		consumed := trace.SpanContextFromContext(propagator.Extract(context.Background(), headerCarrier(headers)))
+
+		assert.Equal(t, published.TraceID(), consumed.TraceID())
+		assert.Equal(t, published.SpanID(), consumed.SpanID())
+	})
+
+	t.Run("messages without trace context start a new trace", func(t *testing.T) {
+		consumed := trace.SpanContextFromContext(propagator.Extract(context.Background(), headerCarrier(nil)))
+
+		assert.False(t, consumed.IsValid())
+	})
+}
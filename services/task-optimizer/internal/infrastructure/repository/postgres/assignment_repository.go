@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-optimizer/internal/domain"
+)
+
+// AssignmentRepository implements domain.AssignmentRepository for PostgreSQL
+type AssignmentRepository struct {
+	db *sql.DB
+}
+
+// NewAssignmentRepository creates a new PostgreSQL assignment repository
+func NewAssignmentRepository(db *sql.DB) *AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+// GetAssignment returns the assignment decided for a task
+func (r *AssignmentRepository) GetAssignment(ctx context.Context, taskID int) (_ *domain.TaskAssignedEvent, err error) {
+	ctx, span := startSpan(ctx, "AssignmentRepository.GetAssignment")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT task_id, user_id, score, reason, alternatives, assigned_at
+		FROM task_assignments
+		WHERE task_id = $1
+	`
+
+	var event domain.TaskAssignedEvent
+	var alternativesJSON []byte
+
+	err = r.db.QueryRowContext(ctx, query, taskID).Scan(
+		&event.TaskID,
+		&event.AssigneeID,
+		&event.Score,
+		&event.Reason,
+		&alternativesJSON,
+		&event.AssignedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAssignmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+
+	if len(alternativesJSON) > 0 {
+		if err := json.Unmarshal(alternativesJSON, &event.Alternatives); err != nil {
+			return nil, fmt.Errorf("failed to decode alternatives: %w", err)
+		}
+	}
+
+	return &event, nil
+}
+
+// CreateAssignment stores the assignment, books its load in the ledger and queues its
+// task.assigned event in the outbox within one transaction, unless the task already has one.
+// Concurrent deliveries of the same task all get the assignment stored first; created is
+// true only for the delivery that stored it.
+func (r *AssignmentRepository) CreateAssignment(
+	ctx context.Context,
+	event domain.TaskAssignedEvent,
+	load domain.LoadEntry,
+) (_ *domain.TaskAssignedEvent, created bool, err error) {
+	ctx, span := startSpan(ctx, "AssignmentRepository.CreateAssignment")
+	defer func() { endSpan(span, err) }()
+
+	alternativesJSON, err := json.Marshal(event.Alternatives)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode alternatives: %w", err)
+	}
+
+	message, err := domain.NewTaskAssignedMessage(event)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `
+		INSERT INTO task_assignments (task_id, user_id, score, reason, alternatives, assigned_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (task_id) DO NOTHING
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		event.TaskID,
+		event.AssigneeID,
+		event.Score,
+		event.Reason,
+		alternativesJSON,
+		event.AssignedAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	// Another delivery already decided, leave its load and event untouched
+	if inserted == 0 {
+		if err := tx.Commit(); err != nil {
+			return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		stored, err := r.GetAssignment(ctx, event.TaskID)
+		return stored, false, err
+	}
+
+	if err := bookLoad(ctx, tx, load); err != nil {
+		return nil, false, fmt.Errorf("failed to update user load: %w", err)
+	}
+
+	if err := enqueue(ctx, tx, message); err != nil {
+		return nil, false, fmt.Errorf("failed to enqueue event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &event, true, nil
+}
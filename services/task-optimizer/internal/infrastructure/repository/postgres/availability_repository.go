@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// AvailabilityRepository implements domain.AvailabilityRepository for PostgreSQL
+type AvailabilityRepository struct {
+	db *sql.DB
+}
+
+// NewAvailabilityRepository creates a new PostgreSQL availability repository
+func NewAvailabilityRepository(db *sql.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+// GetAvailability returns working days from users and absences from user_absences overlapping the period
+func (r *AvailabilityRepository) GetAvailability(
+	ctx context.Context,
+	userIDs []int,
+	from, to time.Time,
+) (_ map[int]domain.Availability, err error) {
+	ctx, span := startSpan(ctx, "AvailabilityRepository.GetAvailability")
+	defer func() { endSpan(span, err) }()
+
+	availability := make(map[int]domain.Availability)
+	if len(userIDs) == 0 {
+		return availability, nil
+	}
+
+	if err := r.loadWorkingDays(ctx, userIDs, availability); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadAbsences(ctx, userIDs, from, to, availability); err != nil {
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+func (r *AvailabilityRepository) loadWorkingDays(
+	ctx context.Context,
+	userIDs []int,
+	availability map[int]domain.Availability,
+) error {
+	query := `
+		SELECT id, working_days
+		FROM users
+		WHERE id = ANY($1) AND working_days IS NOT NULL
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return fmt.Errorf("failed to query working days: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var workingDaysJSON []byte
+
+		if err := rows.Scan(&userID, &workingDaysJSON); err != nil {
+			return fmt.Errorf("failed to scan working days: %w", err)
+		}
+
+		// Laravel stores ISO-8601 weekdays, 1 is Monday and 7 is Sunday
+		var isoDays []int
+		if err := json.Unmarshal(workingDaysJSON, &isoDays); err != nil {
+			continue
+		}
+
+		userAvailability := availability[userID]
+		for _, day := range isoDays {
+			if day >= 1 && day <= 7 {
+				userAvailability.WorkingDays = append(userAvailability.WorkingDays, time.Weekday(day%7))
+			}
+		}
+		availability[userID] = userAvailability
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating working days: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AvailabilityRepository) loadAbsences(
+	ctx context.Context,
+	userIDs []int,
+	from, to time.Time,
+	availability map[int]domain.Availability,
+) error {
+	query := `
+		SELECT user_id, type, starts_on, ends_on
+		FROM user_absences
+		WHERE user_id = ANY($1)
+		  AND starts_on <= $3
+		  AND ends_on >= $2
+		ORDER BY user_id, starts_on
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs), from, to)
+	if err != nil {
+		return fmt.Errorf("failed to query absences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var absence domain.Absence
+
+		if err := rows.Scan(&userID, &absence.Kind, &absence.Start, &absence.End); err != nil {
+			return fmt.Errorf("failed to scan absence: %w", err)
+		}
+
+		userAvailability := availability[userID]
+		userAvailability.Absences = append(userAvailability.Absences, absence)
+		availability[userID] = userAvailability
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating absences: %w", err)
+	}
+
+	return nil
+}
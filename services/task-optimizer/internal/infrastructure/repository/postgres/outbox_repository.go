@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// OutboxRepository implements domain.OutboxRepository for PostgreSQL
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Enqueue adds a message to the outbox
+func (r *OutboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) (err error) {
+	ctx, span := startSpan(ctx, "OutboxRepository.Enqueue")
+	defer func() { endSpan(span, err) }()
+
+	if err := enqueue(ctx, r.db, message); err != nil {
+		return fmt.Errorf("failed to enqueue message: %w", err)
+	}
+	return nil
+}
+
+// ClaimPending locks up to limit unsent messages for the lease duration, oldest first.
+// SKIP LOCKED lets several relays run side by side without sending a message twice.
+func (r *OutboxRepository) ClaimPending(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) (_ []domain.OutboxMessage, err error) {
+	ctx, span := startSpan(ctx, "OutboxRepository.ClaimPending")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE outbox_messages
+		SET attempts = attempts + 1,
+			locked_until = NOW() + make_interval(secs => $2),
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM outbox_messages
+			WHERE sent_at IS NULL
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, routing_key, payload, attempts, created_at, trace_context
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]domain.OutboxMessage, 0)
+
+	for rows.Next() {
+		var message domain.OutboxMessage
+		var traceContextJSON []byte
+		err := rows.Scan(
+			&message.ID,
+			&message.RoutingKey,
+			&message.Payload,
+			&message.Attempts,
+			&message.CreatedAt,
+			&traceContextJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		if len(traceContextJSON) > 0 {
+			if err := json.Unmarshal(traceContextJSON, &message.TraceContext); err != nil {
+				return nil, fmt.Errorf("failed to decode trace context: %w", err)
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox messages: %w", err)
+	}
+
+	// RETURNING does not keep the subquery order
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return messages, nil
+}
+
+// MarkSent records that the message was confirmed by the broker
+func (r *OutboxRepository) MarkSent(ctx context.Context, id int64) (err error) {
+	ctx, span := startSpan(ctx, "OutboxRepository.MarkSent")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE outbox_messages
+		SET sent_at = NOW(), locked_until = NULL, last_error = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message sent: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt and keeps the message locked until retryAfter has passed
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, reason string, retryAfter time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "OutboxRepository.MarkFailed")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		UPDATE outbox_messages
+		SET locked_until = NOW() + make_interval(secs => $3), last_error = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id, reason, retryAfter.Seconds()); err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
+
+// enqueue inserts an outbox message, inside a transaction when db is a *sql.Tx.
+// The trace context of ctx is stored so the relay continues the trace when publishing.
+func enqueue(ctx context.Context, db execer, message domain.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox_messages (routing_key, payload, trace_context, attempts, created_at, updated_at)
+		VALUES ($1, $2, $3, 0, NOW(), NOW())
+	`
+
+	traceContext := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, traceContext)
+
+	var traceContextJSON []byte
+	if len(traceContext) > 0 {
+		encoded, err := json.Marshal(traceContext)
+		if err != nil {
+			return fmt.Errorf("failed to encode trace context: %w", err)
+		}
+		traceContextJSON = encoded
+	}
+
+	_, err := db.ExecContext(ctx, query, message.RoutingKey, message.Payload, traceContextJSON)
+	return err
+}
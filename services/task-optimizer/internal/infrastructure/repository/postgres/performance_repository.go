@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PerformanceRepository implements domain.PerformanceRepository for PostgreSQL
+type PerformanceRepository struct {
+	db                *sql.DB
+	estimateTolerance float64
+}
+
+// NewPerformanceRepository creates a new PostgreSQL performance repository.
+// A task counts as done on estimate when the logged hours stay within
+// estimated_hours multiplied by estimateTolerance.
+func NewPerformanceRepository(db *sql.DB, estimateTolerance float64) *PerformanceRepository {
+	return &PerformanceRepository{
+		db:                db,
+		estimateTolerance: estimateTolerance,
+	}
+}
+
+// GetPerformanceStats aggregates completed tasks from task_logs per user and per required skill
+func (r *PerformanceRepository) GetPerformanceStats(
+	ctx context.Context,
+	userIDs []int,
+	since time.Time,
+) (_ map[int]domain.PerformanceStats, err error) {
+	ctx, span := startSpan(ctx, "PerformanceRepository.GetPerformanceStats")
+	defer func() { endSpan(span, err) }()
+
+	stats := make(map[int]domain.PerformanceStats)
+	if len(userIDs) == 0 {
+		return stats, nil
+	}
+
+	query := `
+		WITH completed AS (
+			SELECT DISTINCT task_id, user_id
+			FROM task_logs
+			WHERE action = 'completed'
+			  AND logged_at >= $2
+			  AND user_id = ANY($1)
+		),
+		spent AS (
+			SELECT task_id, user_id, SUM(hours_spent) as hours
+			FROM task_logs
+			WHERE user_id = ANY($1)
+			GROUP BY task_id, user_id
+		),
+		delivered AS (
+			SELECT
+				completed.task_id,
+				completed.user_id,
+				tasks.required_skills,
+				COALESCE(NULLIF(spent.hours, 0), tasks.actual_hours, 0) <= tasks.estimated_hours * $3 as on_estimate
+			FROM completed
+			JOIN tasks ON tasks.id = completed.task_id
+			LEFT JOIN spent ON spent.task_id = completed.task_id AND spent.user_id = completed.user_id
+			WHERE tasks.estimated_hours > 0
+		)
+		SELECT
+			delivered.user_id,
+			GROUPING(task_skills.skill) = 1 as overall,
+			COALESCE(task_skills.skill, '') as skill,
+			COUNT(DISTINCT delivered.task_id) as completed,
+			COUNT(DISTINCT delivered.task_id) FILTER (WHERE delivered.on_estimate) as on_estimate
+		FROM delivered
+		LEFT JOIN LATERAL (
+			SELECT LOWER(TRIM(COALESCE(
+				element->>'name',
+				split_part(element #>> '{}', ':', 1)
+			))) as skill
+			FROM jsonb_array_elements(COALESCE(delivered.required_skills::jsonb, '[]'::jsonb)) as element
+		) task_skills ON TRUE
+		GROUP BY GROUPING SETS ((delivered.user_id), (delivered.user_id, task_skills.skill))
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs), since, r.estimateTolerance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query performance stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var overall bool
+		var skill string
+		var delivery domain.DeliveryStats
+
+		if err := rows.Scan(&userID, &overall, &skill, &delivery.Completed, &delivery.OnEstimate); err != nil {
+			return nil, fmt.Errorf("failed to scan performance stats: %w", err)
+		}
+
+		userStats, ok := stats[userID]
+		if !ok {
+			userStats.BySkill = make(map[string]domain.DeliveryStats)
+		}
+
+		switch {
+		case overall:
+			userStats.Overall = delivery
+		case skill != "":
+			userStats.BySkill[skill] = delivery
+		}
+
+		stats[userID] = userStats
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating performance stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ProjectActivityRepository implements domain.ProjectActivityRepository for PostgreSQL
+type ProjectActivityRepository struct {
+	db *sql.DB
+}
+
+// NewProjectActivityRepository creates a new PostgreSQL project activity repository
+func NewProjectActivityRepository(db *sql.DB) *ProjectActivityRepository {
+	return &ProjectActivityRepository{db: db}
+}
+
+// GetProjectActivity counts open and recently completed project tasks per assignee
+func (r *ProjectActivityRepository) GetProjectActivity(
+	ctx context.Context,
+	projectID int,
+	userIDs []int,
+	since time.Time,
+) (_ map[int]domain.ProjectActivity, err error) {
+	ctx, span := startSpan(ctx, "ProjectActivityRepository.GetProjectActivity")
+	defer func() { endSpan(span, err) }()
+
+	activity := make(map[int]domain.ProjectActivity)
+	if len(userIDs) == 0 {
+		return activity, nil
+	}
+
+	query := `
+		SELECT
+			assigned_user_id,
+			COUNT(*) FILTER (WHERE status NOT IN ('completed', 'cancelled')) as open_tasks,
+			COUNT(*) FILTER (WHERE status = 'completed' AND updated_at >= $3) as completed_tasks
+		FROM tasks
+		WHERE project_id = $1
+		  AND assigned_user_id = ANY($2)
+		GROUP BY project_id, assigned_user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID, pq.Array(userIDs), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query project activity: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		var userActivity domain.ProjectActivity
+
+		if err := rows.Scan(&userID, &userActivity.OpenTasks, &userActivity.CompletedTasks); err != nil {
+			return nil, fmt.Errorf("failed to scan project activity: %w", err)
+		}
+
+		activity[userID] = userActivity
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating project activity: %w", err)
+	}
+
+	return activity, nil
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-optimizer/internal/domain"
+)
+
+// SkillRepository implements domain.SkillRepository for PostgreSQL
+type SkillRepository struct {
+	db *sql.DB
+}
+
+// NewSkillRepository creates a new PostgreSQL skill repository
+func NewSkillRepository(db *sql.DB) *SkillRepository {
+	return &SkillRepository{db: db}
+}
+
+// GetSkills returns all skills of the catalog
+func (r *SkillRepository) GetSkills(ctx context.Context) (_ []domain.SkillDefinition, err error) {
+	ctx, span := startSpan(ctx, "SkillRepository.GetSkills")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT name, category, is_active
+		FROM skills
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skills: %w", err)
+	}
+	defer rows.Close()
+
+	skills := make([]domain.SkillDefinition, 0)
+
+	for rows.Next() {
+		var skill domain.SkillDefinition
+		if err := rows.Scan(&skill.Name, &skill.Category, &skill.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan skill: %w", err)
+		}
+		skills = append(skills, skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skills: %w", err)
+	}
+
+	return skills, nil
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"task-optimizer/internal/domain"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the repository queries
+var tracer = otel.Tracer("task-optimizer/internal/infrastructure/repository/postgres")
+
+// startSpan starts the span of a repository operation
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+		),
+	)
+}
+
+// endSpan records the error of the operation, if any, and ends its span.
+// A missing assignment is the expected answer for a new task, not a failure.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, domain.ErrAssignmentNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -4,40 +4,66 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"task-optimizer/internal/domain"
 
 	_ "github.com/lib/pq"
 )
 
+// userColumns selects user fields together with raw load data for all capacity models.
+// Open tasks are attributed to their assignee in the load ledger first, so tasks assigned
+// by this service count before Laravel syncs tasks.assigned_user_id.
+const userColumns = `
+	SELECT
+		users.id,
+		users.name,
+		users.email,
+		users.role,
+		users.skills,
+		COALESCE(open_tasks.task_count, 0) as open_tasks,
+		COALESCE(open_tasks.booked_hours, 0) as booked_hours,
+		COALESCE(open_tasks.remaining_hours, 0) as remaining_hours,
+		COALESCE(open_tasks.unestimated_count, 0) as unestimated_tasks,
+		users.max_workload
+	FROM users
+	LEFT JOIN (
+		SELECT
+			COALESCE(ledger.user_id, tasks.assigned_user_id) as assigned_user_id,
+			COUNT(*) as task_count,
+			SUM(COALESCE(ledger.estimated_hours, tasks.estimated_hours)) as booked_hours,
+			SUM(GREATEST(COALESCE(ledger.estimated_hours, tasks.estimated_hours) - tasks.actual_hours, 0)) as remaining_hours,
+			COUNT(*) FILTER (WHERE COALESCE(ledger.estimated_hours, tasks.estimated_hours) IS NULL) as unestimated_count
+		FROM tasks
+		LEFT JOIN task_load_ledger ledger ON ledger.task_id = tasks.id AND ledger.released_at IS NULL
+		WHERE tasks.status NOT IN ('completed', 'cancelled')
+		GROUP BY COALESCE(ledger.user_id, tasks.assigned_user_id)
+	) open_tasks ON open_tasks.assigned_user_id = users.id
+`
+
 // UserRepository implements domain.UserRepository for PostgreSQL
 type UserRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	capacity domain.CapacityPolicy
 }
 
 // NewUserRepository creates a new PostgreSQL user repository
-func NewUserRepository(db *sql.DB) *UserRepository {
-	return &UserRepository{db: db}
+func NewUserRepository(db *sql.DB, capacity domain.CapacityPolicy) *UserRepository {
+	return &UserRepository{
+		db:       db,
+		capacity: capacity,
+	}
 }
 
 // GetActiveUsers returns all active users with role 'User'
-func (r *UserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, error) {
-	query := `
-		SELECT
-			id,
-			name,
-			email,
-			role,
-			skills,
-			COALESCE(
-				(SELECT COUNT(*) FROM tasks WHERE assignee_id = users.id AND status != 'completed'),
-				0
-			) as current_load,
-			10 as max_capacity
-		FROM users
-		WHERE role = 'User'
-		  AND deleted_at IS NULL
-		ORDER BY id
+func (r *UserRepository) GetActiveUsers(ctx context.Context) (_ []domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetActiveUsers")
+	defer func() { endSpan(span, err) }()
+
+	query := userColumns + `
+		WHERE users.role = 'User'
+		  AND users.deleted_at IS NULL
+		ORDER BY users.id
 	`
 
 	rows, err := r.db.QueryContext(ctx, query)
@@ -49,31 +75,12 @@ func (r *UserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, err
 	users := make([]domain.User, 0)
 
 	for rows.Next() {
-		var user domain.User
-		var skillsJSON []byte
-
-		err := rows.Scan(
-			&user.ID,
-			&user.Name,
-			&user.Email,
-			&user.Role,
-			&skillsJSON,
-			&user.CurrentLoad,
-			&user.MaxCapacity,
-		)
+		user, err := r.scanUser(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 
-		if len(skillsJSON) > 0 {
-			if err := json.Unmarshal(skillsJSON, &user.Skills); err != nil {
-				user.Skills = []string{}
-			}
-		} else {
-			user.Skills = []string{}
-		}
-
-		users = append(users, user)
+		users = append(users, *user)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -84,60 +91,171 @@ func (r *UserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, err
 }
 
 // GetUserByID returns a user by ID
-func (r *UserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+func (r *UserRepository) GetUserByID(ctx context.Context, id int) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserRepository.GetUserByID")
+	defer func() { endSpan(span, err) }()
+
+	query := userColumns + `
+		WHERE users.id = $1 AND users.deleted_at IS NULL
+	`
+
+	user, err := r.scanUser(r.db.QueryRowContext(ctx, query, id))
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", domain.ErrUserNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUserLoad books the task on the user in the load ledger, moving it from any previous assignee
+func (r *UserRepository) UpdateUserLoad(ctx context.Context, entry domain.LoadEntry) (err error) {
+	ctx, span := startSpan(ctx, "UserRepository.UpdateUserLoad")
+	defer func() { endSpan(span, err) }()
+
+	if err := bookLoad(ctx, r.db, entry); err != nil {
+		return fmt.Errorf("failed to update user load: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseUserLoad frees the capacity the task holds in the load ledger and returns
+// the user it was booked on, or 0 when the task was not booked
+func (r *UserRepository) ReleaseUserLoad(ctx context.Context, taskID int) (_ int, err error) {
+	ctx, span := startSpan(ctx, "UserRepository.ReleaseUserLoad")
+	defer func() { endSpan(span, err) }()
+
 	query := `
-		SELECT
-			id,
-			name,
-			email,
-			role,
-			skills,
-			COALESCE(
-				(SELECT COUNT(*) FROM tasks WHERE assignee_id = users.id AND status != 'completed'),
-				0
-			) as current_load,
-			10 as max_capacity
-		FROM users
-		WHERE id = $1 AND deleted_at IS NULL
+		UPDATE task_load_ledger
+		SET released_at = NOW(), updated_at = NOW()
+		WHERE task_id = $1 AND released_at IS NULL
+		RETURNING user_id
+	`
+
+	var userID int
+	err = r.db.QueryRowContext(ctx, query, taskID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to release user load: %w", err)
+	}
+
+	return userID, nil
+}
+
+// BookedTasksByUser counts the open tasks booked on each user in the load ledger
+func (r *UserRepository) BookedTasksByUser(ctx context.Context) (_ map[int]int, err error) {
+	ctx, span := startSpan(ctx, "UserRepository.BookedTasksByUser")
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT ledger.user_id, COUNT(*)
+		FROM task_load_ledger ledger
+		JOIN tasks ON tasks.id = ledger.task_id
+		WHERE ledger.released_at IS NULL
+		  AND tasks.status NOT IN ('completed', 'cancelled')
+		GROUP BY ledger.user_id
 	`
 
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booked tasks: %w", err)
+	}
+	defer rows.Close()
+
+	booked := make(map[int]int)
+	for rows.Next() {
+		var userID, tasks int
+		if err := rows.Scan(&userID, &tasks); err != nil {
+			return nil, fmt.Errorf("failed to scan booked tasks: %w", err)
+		}
+		booked[userID] = tasks
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating booked tasks: %w", err)
+	}
+
+	return booked, nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// bookLoad upserts the ledger entry of a task
+func bookLoad(ctx context.Context, db execer, entry domain.LoadEntry) error {
+	query := `
+		INSERT INTO task_load_ledger (task_id, user_id, estimated_hours, released_at, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, 0), NULL, NOW(), NOW())
+		ON CONFLICT (task_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			estimated_hours = EXCLUDED.estimated_hours,
+			released_at = NULL,
+			updated_at = NOW()
+	`
+
+	_, err := db.ExecContext(ctx, query, entry.TaskID, entry.UserID, entry.EstimatedHours)
+	return err
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser reads a row selected with userColumns and applies the capacity model
+func (r *UserRepository) scanUser(row rowScanner) (*domain.User, error) {
 	var user domain.User
 	var skillsJSON []byte
+	var openTasks, bookedHours, remainingHours, unestimatedTasks, maxWorkload int
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
 		&user.Role,
 		&skillsJSON,
-		&user.CurrentLoad,
-		&user.MaxCapacity,
+		&openTasks,
+		&bookedHours,
+		&remainingHours,
+		&unestimatedTasks,
+		&maxWorkload,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("user not found: %d", id)
-	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, err
 	}
 
 	if len(skillsJSON) > 0 {
 		if err := json.Unmarshal(skillsJSON, &user.Skills); err != nil {
-			user.Skills = []string{}
+			user.Skills = domain.SkillSet{}
 		}
 	} else {
-		user.Skills = []string{}
+		user.Skills = domain.SkillSet{}
 	}
 
-	return &user, nil
-}
+	// Tasks without an estimate still occupy the user, so assume the default size
+	unestimatedHours := unestimatedTasks * r.capacity.DefaultTaskHours
+	user.QueuedHours = remainingHours + unestimatedHours
+	user.WeeklyHours = r.capacity.HourCapacity(maxWorkload)
 
-// UpdateUserLoad updates the current load of a user
-func (r *UserRepository) UpdateUserLoad(ctx context.Context, userID int, increment int) error {
-	_, err := r.GetUserByID(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to update user load: %w", err)
+	switch r.capacity.Model {
+	case domain.CapacityModelRemainingHours:
+		user.CurrentLoad = user.QueuedHours
+		user.MaxCapacity = user.WeeklyHours
+	case domain.CapacityModelHours:
+		user.CurrentLoad = bookedHours + unestimatedHours
+		user.MaxCapacity = user.WeeklyHours
+	default:
+		user.CurrentLoad = openTasks
+		user.MaxCapacity = r.capacity.TaskCapacity(maxWorkload)
 	}
 
-	return nil
+	return &user, nil
 }
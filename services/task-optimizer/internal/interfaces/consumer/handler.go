@@ -33,17 +33,17 @@ func (h *TaskEventHandler) HandleTaskCreated(ctx context.Context, event domain.T
 		zap.String("title", event.Title),
 	)
 
-	if err := h.validateEvent(event); err != nil {
+	task := event.ToTask()
+
+	if err := task.Validate(); err != nil {
 		h.logger.Error("Invalid event",
 			zap.Error(err),
 			zap.Int("task_id", event.TaskID),
 		)
-		return fmt.Errorf("validation failed: %w", err)
+		return fmt.Errorf("%w: %w", domain.ErrInvalidEvent, err)
 	}
 
-	task := event.ToTask()
-
-	if err := h.assignTaskUC.Execute(ctx, task); err != nil {
+	if _, err := h.assignTaskUC.Execute(ctx, task); err != nil {
 		h.logger.Error("Failed to execute assign task use case",
 			zap.Error(err),
 			zap.Int("task_id", event.TaskID),
@@ -53,24 +53,3 @@ func (h *TaskEventHandler) HandleTaskCreated(ctx context.Context, event domain.T
 
 	return nil
 }
-
-// validateEvent validates the task created event
-func (h *TaskEventHandler) validateEvent(event domain.TaskCreatedEvent) error {
-	if event.TaskID <= 0 {
-		return fmt.Errorf("invalid task_id: %d", event.TaskID)
-	}
-
-	if event.Title == "" {
-		return fmt.Errorf("title cannot be empty")
-	}
-
-	if event.Priority < 1 || event.Priority > 5 {
-		return fmt.Errorf("priority must be between 1 and 5, got: %d", event.Priority)
-	}
-
-	if event.ProjectID <= 0 {
-		return fmt.Errorf("invalid project_id: %d", event.ProjectID)
-	}
-
-	return nil
-}
@@ -0,0 +1,89 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Task statuses that no longer occupy capacity
+const (
+	statusCompleted = "completed"
+	statusCancelled = "cancelled"
+)
+
+// TaskLifecycleHandler handles events that release or adjust capacity of assigned tasks
+type TaskLifecycleHandler struct {
+	trackLoadUC *application.TrackLoadUseCase
+	logger      *zap.Logger
+}
+
+// NewTaskLifecycleHandler creates a new task lifecycle handler
+func NewTaskLifecycleHandler(
+	trackLoadUC *application.TrackLoadUseCase,
+	logger *zap.Logger,
+) *TaskLifecycleHandler {
+	return &TaskLifecycleHandler{
+		trackLoadUC: trackLoadUC,
+		logger:      logger,
+	}
+}
+
+// HandleTaskCompleted handles the task.completed event
+func (h *TaskLifecycleHandler) HandleTaskCompleted(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	return h.handle(ctx, domain.EventTaskCompleted, event, h.trackLoadUC.Release)
+}
+
+// HandleTaskCancelled handles the task.cancelled event
+func (h *TaskLifecycleHandler) HandleTaskCancelled(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	return h.handle(ctx, domain.EventTaskCancelled, event, h.trackLoadUC.Release)
+}
+
+// HandleTaskReassigned handles the task.reassigned event
+func (h *TaskLifecycleHandler) HandleTaskReassigned(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	return h.handle(ctx, domain.EventTaskReassigned, event, h.trackLoadUC.Rebook)
+}
+
+// HandleTaskUpdated handles the task.updated event.
+// An update that closes the task releases it, any other update rebooks its estimate.
+func (h *TaskLifecycleHandler) HandleTaskUpdated(ctx context.Context, event domain.TaskLifecycleEvent) error {
+	if event.Status == statusCompleted || event.Status == statusCancelled {
+		return h.handle(ctx, domain.EventTaskUpdated, event, h.trackLoadUC.Release)
+	}
+	return h.handle(ctx, domain.EventTaskUpdated, event, h.trackLoadUC.Rebook)
+}
+
+func (h *TaskLifecycleHandler) handle(
+	ctx context.Context,
+	name string,
+	event domain.TaskLifecycleEvent,
+	apply func(context.Context, domain.TaskLifecycleEvent) error,
+) error {
+	h.logger.Info("Handling task lifecycle event",
+		zap.String("event", name),
+		zap.Int("task_id", event.TaskID),
+		zap.Int("assignee_id", event.AssigneeID),
+	)
+
+	if event.TaskID <= 0 {
+		h.logger.Error("Invalid event",
+			zap.String("event", name),
+			zap.Int("task_id", event.TaskID),
+		)
+		return fmt.Errorf("%w: invalid task_id: %d", domain.ErrInvalidEvent, event.TaskID)
+	}
+
+	if err := apply(ctx, event); err != nil {
+		h.logger.Error("Failed to apply task lifecycle event",
+			zap.String("event", name),
+			zap.Error(err),
+			zap.Int("task_id", event.TaskID),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"fmt"
+	optimizerv1 "task-optimizer/api/optimizer/v1"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// taskFromProto converts a protobuf task to the domain model
+func taskFromProto(task *optimizerv1.Task) (domain.Task, error) {
+	if task == nil {
+		return domain.Task{}, fmt.Errorf("task is required")
+	}
+
+	skills := make([]domain.SkillRequirement, 0, len(task.GetSkills()))
+	for _, skill := range task.GetSkills() {
+		skills = append(skills, domain.SkillRequirement{
+			Name:     skill.GetName(),
+			MinLevel: int(skill.GetMinLevel()),
+			Weight:   skill.GetWeight(),
+		})
+	}
+
+	var dueDate *time.Time
+	if task.GetDueDate() != "" {
+		date, err := time.Parse(domain.DateLayout, task.GetDueDate())
+		if err != nil {
+			return domain.Task{}, fmt.Errorf("invalid due_date %q: expected %s", task.GetDueDate(), domain.DateLayout)
+		}
+		dueDate = &date
+	}
+
+	return domain.Task{
+		ID:             int(task.GetTaskId()),
+		Title:          task.GetTitle(),
+		Description:    task.GetDescription(),
+		Priority:       int(task.GetPriority()),
+		ProjectID:      int(task.GetProjectId()),
+		Skills:         skills,
+		EstimatedHours: int(task.GetEstimatedHours()),
+		DueDate:        dueDate,
+	}, nil
+}
+
+// candidateToProto converts a scored user with its factor breakdown
+func candidateToProto(result domain.AssignmentResult) *optimizerv1.Candidate {
+	factors := make([]*optimizerv1.Factor, 0, len(result.Factors))
+	for _, factor := range result.Factors {
+		factors = append(factors, &optimizerv1.Factor{
+			Name:        factor.Name,
+			Weight:      factor.Weight,
+			Score:       factor.Score,
+			Explanation: factor.Explanation,
+		})
+	}
+
+	return &optimizerv1.Candidate{
+		UserId:        int64(result.UserID),
+		UserName:      result.UserName,
+		Score:         result.TotalScore,
+		SkillScore:    result.SkillScore,
+		LoadScore:     result.LoadScore,
+		PriorityBonus: result.PriorityBonus,
+		Reason:        result.Reason,
+		Factors:       factors,
+	}
+}
+
+// exclusionToProto converts the reason a user is not a candidate
+func exclusionToProto(exclusion domain.Exclusion) *optimizerv1.Exclusion {
+	return &optimizerv1.Exclusion{
+		UserId:   int64(exclusion.UserID),
+		UserName: exclusion.UserName,
+		Reason:   exclusion.Reason,
+	}
+}
+
+// assignmentToProto converts a stored assignment
+func assignmentToProto(event domain.TaskAssignedEvent) *optimizerv1.Assignment {
+	alternatives := make([]*optimizerv1.Candidate, 0, len(event.Alternatives))
+	for _, alternative := range event.Alternatives {
+		alternatives = append(alternatives, &optimizerv1.Candidate{
+			UserId:        int64(alternative.UserID),
+			UserName:      alternative.UserName,
+			Score:         alternative.Score,
+			SkillScore:    alternative.SkillScore,
+			LoadScore:     alternative.LoadScore,
+			PriorityBonus: alternative.PriorityBonus,
+			Reason:        alternative.Reason,
+		})
+	}
+
+	return &optimizerv1.Assignment{
+		TaskId:       int64(event.TaskID),
+		AssigneeId:   int64(event.AssigneeID),
+		Score:        event.Score,
+		Reason:       event.Reason,
+		Alternatives: alternatives,
+		AssignedAt:   timestamppb.New(event.AssignedAt),
+	}
+}
+
+// failureToProto converts a batch task whose assignment failed, mapping its error like toStatus
+func failureToProto(failure application.TaskFailure) *optimizerv1.TaskFailure {
+	st := status.Convert(toStatus(failure.Err))
+
+	return &optimizerv1.TaskFailure{
+		TaskId:  int64(failure.Task.ID),
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+}
@@ -0,0 +1,232 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	optimizerv1 "task-optimizer/api/optimizer/v1"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Limits on the number of candidates returned by Recommend
+const (
+	defaultRecommendations = 5
+	maxRecommendations     = 50
+)
+
+// OptimizerHandler implements the TaskOptimizer gRPC service on top of the use cases
+type OptimizerHandler struct {
+	optimizerv1.UnimplementedTaskOptimizerServer
+
+	assignTaskUC *application.AssignTaskUseCase
+	recommendUC  *application.RecommendAssigneesUseCase
+	feed         *application.AssignmentFeed
+	logger       *zap.Logger
+}
+
+// NewOptimizerHandler creates a new gRPC optimizer handler
+func NewOptimizerHandler(
+	assignTaskUC *application.AssignTaskUseCase,
+	recommendUC *application.RecommendAssigneesUseCase,
+	feed *application.AssignmentFeed,
+	logger *zap.Logger,
+) *OptimizerHandler {
+	return &OptimizerHandler{
+		assignTaskUC: assignTaskUC,
+		recommendUC:  recommendUC,
+		feed:         feed,
+		logger:       logger,
+	}
+}
+
+// Recommend implements optimizerv1.TaskOptimizerServer
+func (h *OptimizerHandler) Recommend(
+	ctx context.Context,
+	req *optimizerv1.RecommendRequest,
+) (*optimizerv1.RecommendResponse, error) {
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultRecommendations
+	}
+	if limit < 1 || limit > maxRecommendations {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxRecommendations)
+	}
+
+	task, err := draftFromProto(req.GetTask())
+	if err != nil {
+		return nil, err
+	}
+
+	recommendation, err := h.recommendUC.Execute(ctx, task, limit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &optimizerv1.RecommendResponse{}
+	for _, candidate := range recommendation.Candidates {
+		response.Candidates = append(response.Candidates, candidateToProto(candidate))
+	}
+	for _, exclusion := range recommendation.Exclusions {
+		response.Exclusions = append(response.Exclusions, exclusionToProto(exclusion))
+	}
+
+	return response, nil
+}
+
+// Assign implements optimizerv1.TaskOptimizerServer
+func (h *OptimizerHandler) Assign(ctx context.Context, req *optimizerv1.AssignRequest) (*optimizerv1.AssignResponse, error) {
+	task, err := taskFromProto(req.GetTask())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := task.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	assignment, err := h.assignTaskUC.Execute(ctx, task)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &optimizerv1.AssignResponse{Assignment: assignmentToProto(*assignment)}, nil
+}
+
+// BatchAssign implements optimizerv1.TaskOptimizerServer
+func (h *OptimizerHandler) BatchAssign(
+	ctx context.Context,
+	req *optimizerv1.BatchAssignRequest,
+) (*optimizerv1.BatchAssignResponse, error) {
+	tasks := make([]domain.Task, 0, len(req.GetTasks()))
+	seen := make(map[int]bool, len(req.GetTasks()))
+	for _, protoTask := range req.GetTasks() {
+		task, err := taskFromProto(protoTask)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err := task.Validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "task %d: %v", task.ID, err)
+		}
+		// A task listed twice would be matched and committed twice
+		if seen[task.ID] {
+			return nil, status.Errorf(codes.InvalidArgument, "duplicate task %d", task.ID)
+		}
+		seen[task.ID] = true
+		tasks = append(tasks, task)
+	}
+
+	batch, err := h.assignTaskUC.ExecuteBatch(ctx, tasks)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &optimizerv1.BatchAssignResponse{TotalScore: batch.TotalScore}
+	for _, assignment := range batch.Assigned {
+		response.Assignments = append(response.Assignments, assignmentToProto(assignment))
+	}
+	for _, task := range batch.Unassigned {
+		response.UnassignedTaskIds = append(response.UnassignedTaskIds, int64(task.ID))
+	}
+	for _, failure := range batch.Failed {
+		response.Failures = append(response.Failures, failureToProto(failure))
+	}
+
+	return response, nil
+}
+
+// Explain implements optimizerv1.TaskOptimizerServer
+func (h *OptimizerHandler) Explain(ctx context.Context, req *optimizerv1.ExplainRequest) (*optimizerv1.ExplainResponse, error) {
+	if req.GetUserId() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %d", req.GetUserId())
+	}
+
+	task, err := draftFromProto(req.GetTask())
+	if err != nil {
+		return nil, err
+	}
+
+	explanation, err := h.recommendUC.Explain(ctx, task, int(req.GetUserId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &optimizerv1.ExplainResponse{
+		Rank:       int32(explanation.Rank),
+		Candidates: int32(explanation.Candidates),
+	}
+	if explanation.Result != nil {
+		response.Candidate = candidateToProto(*explanation.Result)
+	}
+	if explanation.Exclusion != nil {
+		response.Exclusion = exclusionToProto(*explanation.Exclusion)
+	}
+
+	return response, nil
+}
+
+// WatchAssignments implements optimizerv1.TaskOptimizerServer
+func (h *OptimizerHandler) WatchAssignments(
+	req *optimizerv1.WatchAssignmentsRequest,
+	stream grpc.ServerStreamingServer[optimizerv1.Assignment],
+) error {
+	assignees := make(map[int64]bool, len(req.GetAssigneeIds()))
+	for _, id := range req.GetAssigneeIds() {
+		assignees[id] = true
+	}
+
+	events, unsubscribe := h.feed.Subscribe()
+	defer unsubscribe()
+
+	h.logger.Info("Assignment watcher subscribed", zap.Int64s("assignee_ids", req.GetAssigneeIds()))
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "server is shutting down")
+			}
+			if len(assignees) > 0 && !assignees[int64(event.AssigneeID)] {
+				continue
+			}
+			if err := stream.Send(assignmentToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// draftFromProto converts and validates a task that may still be a draft
+func draftFromProto(protoTask *optimizerv1.Task) (domain.Task, error) {
+	task, err := taskFromProto(protoTask)
+	if err != nil {
+		return domain.Task{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := task.ValidateDraft(); err != nil {
+		return domain.Task{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return task, nil
+}
+
+// toStatus maps use case errors to gRPC status codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNoSuitableUsers):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrCandidateUnavailable):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
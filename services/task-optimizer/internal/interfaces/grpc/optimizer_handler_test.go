@@ -0,0 +1,509 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	optimizerv1 "task-optimizer/api/optimizer/v1"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+	"task-optimizer/pkg/metrics"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MockUserRepository is a mock implementation of domain.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserLoad(ctx context.Context, entry domain.LoadEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReleaseUserLoad(ctx context.Context, taskID int) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockAssignmentRepository is a mock implementation of domain.AssignmentRepository.
+// CreateAssignment stores the given event unless the expectation returns another one.
+type MockAssignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentRepository) GetAssignment(ctx context.Context, taskID int) (*domain.TaskAssignedEvent, error) {
+	args := m.Called(ctx, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TaskAssignedEvent), args.Error(1)
+}
+
+func (m *MockAssignmentRepository) CreateAssignment(
+	ctx context.Context,
+	event domain.TaskAssignedEvent,
+	load domain.LoadEntry,
+) (*domain.TaskAssignedEvent, bool, error) {
+	args := m.Called(ctx, event, load)
+	if err := args.Error(2); err != nil {
+		return nil, false, err
+	}
+	if stored, ok := args.Get(0).(*domain.TaskAssignedEvent); ok {
+		return stored, args.Bool(1), nil
+	}
+	return &event, true, nil
+}
+
+// MockOutboxRepository is a mock implementation of domain.OutboxRepository
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Enqueue(ctx context.Context, message domain.OutboxMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxMessage, error) {
+	args := m.Called(ctx, limit, lease)
+	return args.Get(0).([]domain.OutboxMessage), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkSent(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string, retryAfter time.Duration) error {
+	args := m.Called(ctx, id, reason, retryAfter)
+	return args.Error(0)
+}
+
+// testServer is the gRPC server running in process on a bufconn listener
+type testServer struct {
+	client      optimizerv1.TaskOptimizerClient
+	conn        *grpc.ClientConn
+	users       *MockUserRepository
+	assignments *MockAssignmentRepository
+	outbox      *MockOutboxRepository
+	feed        *application.AssignmentFeed
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	logger := zap.NewNop()
+	ts := &testServer{
+		users:       new(MockUserRepository),
+		assignments: new(MockAssignmentRepository),
+		outbox:      new(MockOutboxRepository),
+		feed:        application.NewAssignmentFeed(logger),
+	}
+
+	capacity := domain.DefaultCapacityPolicy()
+	optimizer := domain.NewOptimizerService(ts.users,
+		domain.WithCapacity(capacity),
+		domain.WithConstraints(domain.CapacityConstraint{Capacity: capacity}),
+	)
+	assignTaskUC := application.NewAssignTaskUseCase(
+		optimizer, ts.assignments, ts.outbox, 2, ts.feed, metrics.Nop{}, logger,
+	)
+	recommendUC := application.NewRecommendAssigneesUseCase(optimizer, logger)
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer("bufnet", NewOptimizerHandler(assignTaskUC, recommendUC, ts.feed, logger), logger)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		ts.feed.Close()
+		conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+
+	ts.conn = conn
+	ts.client = optimizerv1.NewTaskOptimizerClient(conn)
+	return ts
+}
+
+// withUsers makes the users the active team, each reloadable by ID
+func (ts *testServer) withUsers(users ...domain.User) {
+	ts.users.On("GetActiveUsers", mock.Anything).Return(users, nil)
+	for i := range users {
+		ts.users.On("GetUserByID", mock.Anything, users[i].ID).Return(&users[i], nil).Maybe()
+	}
+}
+
+func protoTask(id int64, skills ...string) *optimizerv1.Task {
+	task := &optimizerv1.Task{
+		TaskId:    id,
+		Title:     fmt.Sprintf("Task %d", id),
+		Priority:  3,
+		ProjectId: 1,
+	}
+	for _, skill := range skills {
+		task.Skills = append(task.Skills, &optimizerv1.SkillRequirement{Name: skill})
+	}
+	return task
+}
+
+func taskWithID(id int) interface{} {
+	return mock.MatchedBy(func(event domain.TaskAssignedEvent) bool {
+		return event.TaskID == id
+	})
+}
+
+var (
+	alice = domain.User{ID: 1, Name: "Alice", Skills: domain.SkillsFromNames("go"), CurrentLoad: 1, MaxCapacity: 10}
+	bob   = domain.User{ID: 2, Name: "Bob", Skills: domain.SkillsFromNames("php"), CurrentLoad: 1, MaxCapacity: 10}
+)
+
+func TestRecommend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ranks candidates with factors", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.withUsers(alice, bob)
+
+		response, err := ts.client.Recommend(ctx, &optimizerv1.RecommendRequest{Task: protoTask(0, "go"), Limit: 1})
+
+		require.NoError(t, err)
+		require.Len(t, response.Candidates, 1)
+		assert.Equal(t, int64(1), response.Candidates[0].UserId)
+		assert.NotEmpty(t, response.Candidates[0].Factors)
+		assert.Empty(t, response.Exclusions)
+	})
+
+	t.Run("reports exclusions when nobody is eligible", func(t *testing.T) {
+		ts := newTestServer(t)
+		full := domain.User{ID: 3, Name: "Full", CurrentLoad: 10, MaxCapacity: 10}
+		ts.withUsers(full)
+
+		response, err := ts.client.Recommend(ctx, &optimizerv1.RecommendRequest{Task: protoTask(0)})
+
+		require.NoError(t, err)
+		assert.Empty(t, response.Candidates)
+		require.Len(t, response.Exclusions, 1)
+		assert.Equal(t, int64(3), response.Exclusions[0].UserId)
+		assert.NotEmpty(t, response.Exclusions[0].Reason)
+	})
+
+	tests := []struct {
+		name    string
+		request *optimizerv1.RecommendRequest
+	}{
+		{name: "missing task", request: &optimizerv1.RecommendRequest{}},
+		{name: "limit too large", request: &optimizerv1.RecommendRequest{Task: protoTask(0), Limit: maxRecommendations + 1}},
+		{name: "negative limit", request: &optimizerv1.RecommendRequest{Task: protoTask(0), Limit: -1}},
+		{name: "invalid priority", request: &optimizerv1.RecommendRequest{Task: &optimizerv1.Task{ProjectId: 1}}},
+		{name: "invalid due date", request: &optimizerv1.RecommendRequest{
+			Task: &optimizerv1.Task{Priority: 3, ProjectId: 1, DueDate: "tomorrow"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+
+			_, err := ts.client.Recommend(ctx, tt.request)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestAssign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores and returns a new assignment", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.withUsers(alice, bob)
+		ts.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		ts.assignments.On("CreateAssignment", mock.Anything, taskWithID(10), mock.Anything).Return(nil, true, nil)
+
+		response, err := ts.client.Assign(ctx, &optimizerv1.AssignRequest{Task: protoTask(10, "go")})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), response.Assignment.TaskId)
+		assert.Equal(t, int64(1), response.Assignment.AssigneeId)
+		require.Len(t, response.Assignment.Alternatives, 1)
+		assert.Equal(t, int64(2), response.Assignment.Alternatives[0].UserId)
+		assert.False(t, response.Assignment.AssignedAt.AsTime().IsZero())
+		ts.assignments.AssertExpectations(t)
+	})
+
+	t.Run("returns the stored assignment of an assigned task", func(t *testing.T) {
+		ts := newTestServer(t)
+		assignedAt := time.Date(2025, 11, 3, 9, 30, 0, 0, time.UTC)
+		stored := &domain.TaskAssignedEvent{TaskID: 10, AssigneeID: 2, Score: 0.7, AssignedAt: assignedAt}
+		ts.assignments.On("GetAssignment", mock.Anything, 10).Return(stored, nil)
+		ts.outbox.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+
+		response, err := ts.client.Assign(ctx, &optimizerv1.AssignRequest{Task: protoTask(10, "go")})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), response.Assignment.AssigneeId)
+		assert.Equal(t, assignedAt, response.Assignment.AssignedAt.AsTime())
+		ts.outbox.AssertExpectations(t)
+		ts.users.AssertNotCalled(t, "GetActiveUsers", mock.Anything)
+	})
+
+	t.Run("rejects a task without title", func(t *testing.T) {
+		ts := newTestServer(t)
+		task := protoTask(10)
+		task.Title = ""
+
+		_, err := ts.client.Assign(ctx, &optimizerv1.AssignRequest{Task: task})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("fails the precondition when nobody can take the task", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{}, nil)
+		ts.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+
+		_, err := ts.client.Assign(ctx, &optimizerv1.AssignRequest{Task: protoTask(10)})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestBatchAssign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns stored assignments and per-task failures", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.withUsers(alice, bob)
+		earlier := &domain.TaskAssignedEvent{
+			TaskID:       12,
+			AssigneeID:   2,
+			Score:        0.5,
+			Alternatives: []domain.CandidateSummary{{UserID: 1, UserName: "Alice"}},
+			AssignedAt:   time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC),
+		}
+		ts.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+		ts.assignments.On("GetAssignment", mock.Anything, 11).Return(nil, domain.ErrAssignmentNotFound)
+		ts.assignments.On("GetAssignment", mock.Anything, 12).Return(earlier, nil)
+		ts.assignments.On("CreateAssignment", mock.Anything, taskWithID(10), mock.Anything).Return(nil, true, nil)
+		ts.assignments.On("CreateAssignment", mock.Anything, taskWithID(11), mock.Anything).
+			Return(nil, false, errors.New("connection reset"))
+
+		response, err := ts.client.BatchAssign(ctx, &optimizerv1.BatchAssignRequest{
+			Tasks: []*optimizerv1.Task{protoTask(10, "go"), protoTask(11, "php"), protoTask(12, "php")},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, response.Assignments, 2)
+		assert.Equal(t, int64(10), response.Assignments[0].TaskId)
+		assert.Equal(t, int64(1), response.Assignments[0].AssigneeId)
+		assert.False(t, response.Assignments[0].AssignedAt.AsTime().IsZero())
+		assert.Equal(t, int64(12), response.Assignments[1].TaskId)
+		assert.Equal(t, int64(2), response.Assignments[1].AssigneeId)
+		assert.Len(t, response.Assignments[1].Alternatives, 1)
+		assert.InDelta(t, response.Assignments[0].Score+0.5, response.TotalScore, 1e-9)
+
+		require.Len(t, response.Failures, 1)
+		assert.Equal(t, int64(11), response.Failures[0].TaskId)
+		assert.Equal(t, int32(codes.Internal), response.Failures[0].Code)
+		assert.Contains(t, response.Failures[0].Message, "connection reset")
+		assert.Empty(t, response.UnassignedTaskIds)
+	})
+
+	t.Run("lists tasks nobody can take", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.users.On("GetActiveUsers", mock.Anything).Return([]domain.User{}, nil)
+		ts.assignments.On("GetAssignment", mock.Anything, 10).Return(nil, domain.ErrAssignmentNotFound)
+
+		response, err := ts.client.BatchAssign(ctx, &optimizerv1.BatchAssignRequest{
+			Tasks: []*optimizerv1.Task{protoTask(10)},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, response.Assignments)
+		assert.Equal(t, []int64{10}, response.UnassignedTaskIds)
+	})
+
+	t.Run("rejects an invalid task", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		_, err := ts.client.BatchAssign(ctx, &optimizerv1.BatchAssignRequest{
+			Tasks: []*optimizerv1.Task{protoTask(10), protoTask(0)},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("rejects a duplicate task", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		_, err := ts.client.BatchAssign(ctx, &optimizerv1.BatchAssignRequest{
+			Tasks: []*optimizerv1.Task{protoTask(10), protoTask(11), protoTask(10)},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "duplicate task 10")
+	})
+}
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ranks the user among the candidates", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.withUsers(alice, bob)
+
+		response, err := ts.client.Explain(ctx, &optimizerv1.ExplainRequest{Task: protoTask(0, "php"), UserId: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), response.Rank)
+		assert.Equal(t, int32(2), response.Candidates)
+		require.NotNil(t, response.Candidate)
+		assert.Equal(t, int64(1), response.Candidate.UserId)
+		assert.Nil(t, response.Exclusion)
+	})
+
+	t.Run("unknown user is not found", func(t *testing.T) {
+		ts := newTestServer(t)
+		ts.withUsers(alice, bob)
+
+		_, err := ts.client.Explain(ctx, &optimizerv1.ExplainRequest{Task: protoTask(0), UserId: 99})
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("rejects an invalid user id", func(t *testing.T) {
+		ts := newTestServer(t)
+
+		_, err := ts.client.Explain(ctx, &optimizerv1.ExplainRequest{Task: protoTask(0)})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestWatchAssignments(t *testing.T) {
+	ts := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := ts.client.WatchAssignments(ctx, &optimizerv1.WatchAssignmentsRequest{AssigneeIds: []int64{1}})
+	require.NoError(t, err)
+
+	// The server subscribes asynchronously, so publish until the watcher has seen enough
+	publishing, stopPublishing := context.WithCancel(ctx)
+	defer stopPublishing()
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			ts.feed.Publish(domain.TaskAssignedEvent{TaskID: 20, AssigneeID: 2})
+			ts.feed.Publish(domain.TaskAssignedEvent{TaskID: 21, AssigneeID: 1})
+			select {
+			case <-publishing.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	for i := 0; i < 2; i++ {
+		assignment, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), assignment.TaskId, "assignments to other users are filtered out")
+	}
+	stopPublishing()
+
+	ts.feed.Close()
+
+	for {
+		_, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+			break
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{name: "no suitable users", err: fmt.Errorf("failed to find assignee: %w", domain.ErrNoSuitableUsers), expected: codes.FailedPrecondition},
+		{name: "no suitable users with exclusions", err: &domain.NoSuitableUsersError{}, expected: codes.FailedPrecondition},
+		{name: "candidate unavailable", err: domain.ErrCandidateUnavailable, expected: codes.Aborted},
+		{name: "user not found", err: fmt.Errorf("%w: 7", domain.ErrUserNotFound), expected: codes.NotFound},
+		{name: "canceled", err: context.Canceled, expected: codes.Canceled},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, expected: codes.DeadlineExceeded},
+		{name: "unexpected", err: errors.New("boom"), expected: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+
+			assert.Equal(t, tt.expected, st.Code())
+			assert.Equal(t, tt.err.Error(), st.Message())
+		})
+	}
+}
+
+func TestReflection(t *testing.T) {
+	ts := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := reflectionv1.NewServerReflectionClient(ts.conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+
+	err = stream.Send(&reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(t, err)
+
+	response, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, optimizerv1.TaskOptimizer_ServiceDesc.ServiceName)
+}
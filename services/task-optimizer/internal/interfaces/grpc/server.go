@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	optimizerv1 "task-optimizer/api/optimizer/v1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server serves the gRPC API with reflection enabled
+type Server struct {
+	addr   string
+	server *grpc.Server
+	logger *zap.Logger
+}
+
+// NewServer creates a server listening on addr
+func NewServer(addr string, optimizer *OptimizerHandler, logger *zap.Logger) *Server {
+	server := grpc.NewServer()
+	optimizerv1.RegisterTaskOptimizerServer(server, optimizer)
+	reflection.Register(server)
+
+	return &Server{
+		addr:   addr,
+		server: server,
+		logger: logger,
+	}
+}
+
+// Start binds the listen address and serves requests in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	s.logger.Info("gRPC server listening", zap.String("addr", listener.Addr().String()))
+
+	go s.Serve(listener)
+
+	return nil
+}
+
+// Serve serves requests on the listener until the server stops
+func (s *Server) Serve(listener net.Listener) {
+	if err := s.server.Serve(listener); err != nil {
+		s.logger.Error("gRPC server failed", zap.Error(err))
+	}
+}
+
+// Shutdown stops accepting calls and waits for active ones, cancelling them once ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		s.logger.Info("gRPC server stopped")
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return fmt.Errorf("failed to shut down gRPC server gracefully: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkTimeout bounds how long a single dependency check may take
+const checkTimeout = 2 * time.Second
+
+// HealthCheck returns an error when a dependency cannot serve requests
+type HealthCheck func(ctx context.Context) error
+
+// HealthHandler serves the liveness and readiness probes
+type HealthHandler struct {
+	checks map[string]HealthCheck
+	logger *zap.Logger
+}
+
+// NewHealthHandler creates a health handler reporting ready once every check passes
+func NewHealthHandler(checks map[string]HealthCheck, logger *zap.Logger) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+		logger: logger,
+	}
+}
+
+// healthResponse is the body of GET /healthz and GET /readyz
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+// checkResult is the state of one dependency
+type checkResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HandleLiveness handles GET /healthz, answering as long as the process serves requests
+func (h *HealthHandler) HandleLiveness(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// HandleReadiness handles GET /readyz, running every check and returning 503 if any fails
+func (h *HealthHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]checkResult, len(h.checks))
+		ready   = true
+	)
+
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := checkResult{Status: "up"}
+			if err := check(ctx); err != nil {
+				result = checkResult{Status: "down", Error: err.Error()}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if result.Status != "up" {
+				ready = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !ready {
+		h.logger.Warn("Readiness check failed", zap.Any("checks", results))
+		writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Checks: results})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ready", Checks: results})
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func healthy(context.Context) error { return nil }
+
+func probe(handle http.HandlerFunc, path string) (int, healthResponse) {
+	recorder := httptest.NewRecorder()
+	handle(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body healthResponse
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder.Code, body
+}
+
+func TestHandleLiveness(t *testing.T) {
+	failing := func(context.Context) error { return errors.New("connection refused") }
+	handler := NewHealthHandler(map[string]HealthCheck{"postgres": failing}, zap.NewNop())
+
+	status, body := probe(handler.HandleLiveness, "/healthz")
+
+	assert.Equal(t, http.StatusOK, status, "liveness does not depend on dependencies")
+	assert.Equal(t, "ok", body.Status)
+	assert.Empty(t, body.Checks)
+}
+
+func TestHandleReadiness(t *testing.T) {
+	t.Run("ready when every check passes", func(t *testing.T) {
+		handler := NewHealthHandler(map[string]HealthCheck{
+			"postgres": healthy,
+			"rabbitmq": healthy,
+			"consumer": healthy,
+		}, zap.NewNop())
+
+		status, body := probe(handler.HandleReadiness, "/readyz")
+
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "ready", body.Status)
+		assert.Equal(t, map[string]checkResult{
+			"postgres": {Status: "up"},
+			"rabbitmq": {Status: "up"},
+			"consumer": {Status: "up"},
+		}, body.Checks)
+	})
+
+	t.Run("unavailable with a breakdown when one check fails", func(t *testing.T) {
+		handler := NewHealthHandler(map[string]HealthCheck{
+			"postgres": healthy,
+			"rabbitmq": func(context.Context) error { return errors.New("connection closed") },
+			"consumer": healthy,
+		}, zap.NewNop())
+
+		status, body := probe(handler.HandleReadiness, "/readyz")
+
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, "unavailable", body.Status)
+		assert.Equal(t, map[string]checkResult{
+			"postgres": {Status: "up"},
+			"rabbitmq": {Status: "down", Error: "connection closed"},
+			"consumer": {Status: "up"},
+		}, body.Checks)
+	})
+
+	t.Run("slow check is cut off by the timeout", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("waits for the check timeout")
+		}
+
+		handler := NewHealthHandler(map[string]HealthCheck{
+			"postgres": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, zap.NewNop())
+
+		start := time.Now()
+		status, body := probe(handler.HandleReadiness, "/readyz")
+
+		assert.Less(t, time.Since(start), checkTimeout+time.Second)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		require.Contains(t, body.Checks, "postgres")
+		assert.Equal(t, "down", body.Checks["postgres"].Status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), body.Checks["postgres"].Error)
+	})
+}
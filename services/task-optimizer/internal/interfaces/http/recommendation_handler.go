@@ -0,0 +1,158 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Limits on the number of candidates returned
+const (
+	defaultRecommendations = 5
+	maxRecommendations     = 50
+)
+
+// maxRequestBytes bounds the size of a task payload
+const maxRequestBytes = 1 << 20
+
+// RecommendationHandler serves assignee recommendations for tasks that are not assigned yet
+type RecommendationHandler struct {
+	recommendUC *application.RecommendAssigneesUseCase
+	logger      *zap.Logger
+}
+
+// NewRecommendationHandler creates a new recommendation handler
+func NewRecommendationHandler(
+	recommendUC *application.RecommendAssigneesUseCase,
+	logger *zap.Logger,
+) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendUC: recommendUC,
+		logger:      logger,
+	}
+}
+
+// recommendationResponse is the body of POST /v1/recommendations
+type recommendationResponse struct {
+	Candidates []candidateResponse `json:"candidates"`
+	Exclusions []exclusionResponse `json:"exclusions,omitempty"`
+}
+
+// candidateResponse is a ranked candidate with its factor breakdown
+type candidateResponse struct {
+	UserID        int              `json:"user_id"`
+	UserName      string           `json:"user_name"`
+	Score         float64          `json:"score"`
+	SkillScore    float64          `json:"skill_score"`
+	LoadScore     float64          `json:"load_score"`
+	PriorityBonus float64          `json:"priority_bonus"`
+	Reason        string           `json:"reason"`
+	Factors       []factorResponse `json:"factors"`
+}
+
+// factorResponse is the score of one scoring factor
+type factorResponse struct {
+	Name        string  `json:"name"`
+	Weight      float64 `json:"weight"`
+	Score       float64 `json:"score"`
+	Explanation string  `json:"explanation,omitempty"`
+}
+
+// exclusionResponse explains why a user is not a candidate
+type exclusionResponse struct {
+	UserID   int    `json:"user_id"`
+	UserName string `json:"user_name"`
+	Reason   string `json:"reason"`
+}
+
+// HandleRecommend handles POST /v1/recommendations.
+// The body is a task shaped like the task.created event; task_id and title may be empty
+// for a task that is still being written. The optional limit query parameter caps the candidates.
+func (h *RecommendationHandler) HandleRecommend(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var event domain.TaskCreatedEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&event); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid task payload: %v", err))
+		return
+	}
+
+	task := event.ToTask()
+
+	if err := task.ValidateDraft(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	recommendation, err := h.recommendUC.Execute(r.Context(), task, limit)
+	if err != nil {
+		h.logger.Error("Failed to recommend assignees", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "failed to recommend assignees")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRecommendationResponse(recommendation))
+}
+
+// parseLimit parses the limit query parameter
+func parseLimit(value string) (int, error) {
+	if value == "" {
+		return defaultRecommendations, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxRecommendations {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxRecommendations)
+	}
+
+	return limit, nil
+}
+
+// newRecommendationResponse converts a recommendation to its response body
+func newRecommendationResponse(recommendation *application.Recommendation) recommendationResponse {
+	response := recommendationResponse{
+		Candidates: make([]candidateResponse, 0, len(recommendation.Candidates)),
+	}
+
+	for _, candidate := range recommendation.Candidates {
+		factors := make([]factorResponse, 0, len(candidate.Factors))
+		for _, factor := range candidate.Factors {
+			factors = append(factors, factorResponse{
+				Name:        factor.Name,
+				Weight:      factor.Weight,
+				Score:       factor.Score,
+				Explanation: factor.Explanation,
+			})
+		}
+
+		response.Candidates = append(response.Candidates, candidateResponse{
+			UserID:        candidate.UserID,
+			UserName:      candidate.UserName,
+			Score:         candidate.TotalScore,
+			SkillScore:    candidate.SkillScore,
+			LoadScore:     candidate.LoadScore,
+			PriorityBonus: candidate.PriorityBonus,
+			Reason:        candidate.Reason,
+			Factors:       factors,
+		})
+	}
+
+	for _, exclusion := range recommendation.Exclusions {
+		response.Exclusions = append(response.Exclusions, exclusionResponse{
+			UserID:   exclusion.UserID,
+			UserName: exclusion.UserName,
+			Reason:   exclusion.Reason,
+		})
+	}
+
+	return response
+}
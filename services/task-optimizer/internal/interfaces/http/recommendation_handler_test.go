@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-optimizer/internal/application"
+	"task-optimizer/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockUserRepository is a mock implementation of domain.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetActiveUsers(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserLoad(ctx context.Context, entry domain.LoadEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ReleaseUserLoad(ctx context.Context, taskID int) (int, error) {
+	args := m.Called(ctx, taskID)
+	return args.Int(0), args.Error(1)
+}
+
+func newTestRecommendationHandler(userRepo *MockUserRepository) *RecommendationHandler {
+	capacity := domain.DefaultCapacityPolicy()
+	optimizer := domain.NewOptimizerService(userRepo,
+		domain.WithCapacity(capacity),
+		domain.WithConstraints(domain.CapacityConstraint{Capacity: capacity}),
+	)
+	recommendUC := application.NewRecommendAssigneesUseCase(optimizer, zap.NewNop())
+	return NewRecommendationHandler(recommendUC, zap.NewNop())
+}
+
+func recommend(handler *RecommendationHandler, query, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/v1/recommendations"+query, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.HandleRecommend(recorder, request)
+	return recorder
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		wantErr  bool
+	}{
+		{value: "", expected: defaultRecommendations},
+		{value: "1", expected: 1},
+		{value: "50", expected: maxRecommendations},
+		{value: "0", wantErr: true},
+		{value: "-3", wantErr: true},
+		{value: "51", wantErr: true},
+		{value: "ten", wantErr: true},
+		{value: "2.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			limit, err := parseLimit(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+func TestHandleRecommend(t *testing.T) {
+	users := []domain.User{
+		{ID: 1, Name: "Alice", Skills: domain.SkillsFromNames("go"), CurrentLoad: 2, MaxCapacity: 10},
+		{ID: 2, Name: "Bob", Skills: domain.SkillsFromNames("php"), CurrentLoad: 1, MaxCapacity: 10},
+		{ID: 3, Name: "Carol", Skills: domain.SkillsFromNames("go"), CurrentLoad: 6, MaxCapacity: 10},
+	}
+	draft := `{"priority": 3, "project_id": 1, "skills": [{"name": "go"}]}`
+
+	t.Run("returns ranked candidates up to the limit", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetActiveUsers", mock.Anything).Return(users, nil)
+
+		response := recommend(newTestRecommendationHandler(userRepo), "?limit=2", draft)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+
+		var body recommendationResponse
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		require.Len(t, body.Candidates, 2)
+		assert.Equal(t, 1, body.Candidates[0].UserID)
+		assert.Equal(t, 3, body.Candidates[1].UserID)
+		assert.NotEmpty(t, body.Candidates[0].Factors)
+		assert.Empty(t, body.Exclusions)
+	})
+
+	t.Run("returns only exclusions when nobody is eligible", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetActiveUsers", mock.Anything).Return([]domain.User{
+			{ID: 4, Name: "Dave", Skills: domain.SkillsFromNames("go"), CurrentLoad: 10, MaxCapacity: 10},
+		}, nil)
+
+		response := recommend(newTestRecommendationHandler(userRepo), "", draft)
+
+		require.Equal(t, http.StatusOK, response.Code)
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		assert.JSONEq(t, `[]`, string(body["candidates"]), "candidates is an empty list, not null")
+
+		var exclusions []exclusionResponse
+		require.NoError(t, json.Unmarshal(body["exclusions"], &exclusions))
+		require.Len(t, exclusions, 1)
+		assert.Equal(t, 4, exclusions[0].UserID)
+		assert.Equal(t, "Dave", exclusions[0].UserName)
+		assert.Contains(t, exclusions[0].Reason, "no capacity")
+	})
+
+	tests := []struct {
+		name   string
+		query  string
+		body   string
+		status int
+	}{
+		{name: "limit out of range", query: "?limit=0", body: draft, status: http.StatusBadRequest},
+		{name: "malformed json", body: `{"priority": 3,`, status: http.StatusBadRequest},
+		{name: "wrong field type", body: `{"priority": "high", "project_id": 1}`, status: http.StatusBadRequest},
+		{name: "invalid due date", body: `{"priority": 3, "project_id": 1, "due_date": "soon"}`, status: http.StatusBadRequest},
+		{name: "oversized payload", body: `{"description": "` + strings.Repeat("x", maxRequestBytes) + `"}`, status: http.StatusBadRequest},
+		{name: "priority out of range", body: `{"priority": 9, "project_id": 1}`, status: http.StatusUnprocessableEntity},
+		{name: "missing project", body: `{"priority": 3}`, status: http.StatusUnprocessableEntity},
+		{name: "negative estimate", body: `{"priority": 3, "project_id": 1, "estimated_hours": -2}`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+
+			response := recommend(newTestRecommendationHandler(userRepo), tt.query, tt.body)
+
+			assert.Equal(t, tt.status, response.Code)
+
+			var body errorResponse
+			require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.Error)
+			userRepo.AssertNotCalled(t, "GetActiveUsers", mock.Anything)
+		})
+	}
+
+	t.Run("hides repository failures", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetActiveUsers", mock.Anything).Return(nil, errors.New("pq: connection refused"))
+
+		response := recommend(newTestRecommendationHandler(userRepo), "", draft)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.NotContains(t, response.Body.String(), "pq:")
+	})
+}
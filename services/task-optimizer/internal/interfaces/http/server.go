@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Server serves the HTTP API
+type Server struct {
+	server *http.Server
+	logger *zap.Logger
+}
+
+// NewServer creates a server listening on addr
+func NewServer(
+	addr string,
+	recommendations *RecommendationHandler,
+	health *HealthHandler,
+	metrics http.Handler,
+	logger *zap.Logger,
+) *Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/recommendations", recommendations.HandleRecommend)
+	mux.HandleFunc("GET /healthz", health.HandleLiveness)
+	mux.HandleFunc("GET /readyz", health.HandleReadiness)
+	mux.Handle("GET /metrics", metrics)
+
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Start binds the listen address and serves requests in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	s.logger.Info("HTTP server listening", zap.String("addr", listener.Addr().String()))
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server failed", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops accepting requests and waits for active ones until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down HTTP server: %w", err)
+	}
+
+	s.logger.Info("HTTP server stopped")
+	return nil
+}
+
+// errorResponse is the body of every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes body as a JSON response with the status code
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import "time"
+
+// Outcomes of an assignment attempt
+const (
+	OutcomeAssigned        = "assigned"
+	OutcomeNoSuitableUsers = "no_suitable_users"
+	OutcomeError           = "error"
+)
+
+// Recorder records the service metrics
+type Recorder interface {
+	// MessageConsumed counts a delivery taken from the queue
+	MessageConsumed(queue, routingKey string)
+
+	// MessageAcked counts a delivery handled successfully
+	MessageAcked(queue, routingKey string)
+
+	// MessageNacked counts a delivery returned to the queue
+	MessageNacked(queue, routingKey string)
+
+	// MessageRetried counts a delivery moved to a retry queue
+	MessageRetried(queue, routingKey string)
+
+	// MessageDeadLettered counts a delivery moved to the parking queue
+	MessageDeadLettered(queue, routingKey string)
+
+	// AssignmentObserved records how long an assignment took and how it ended
+	AssignmentObserved(outcome string, duration time.Duration)
+
+	// AssignmentScored records the score of the chosen assignee
+	AssignmentScored(score float64)
+
+	// NoSuitableUsers counts a task of the project that nobody could take
+	NoSuitableUsers(projectID int)
+}
+
+// Nop is a Recorder discarding every metric
+type Nop struct{}
+
+var _ Recorder = Nop{}
+
+func (Nop) MessageConsumed(string, string)           {}
+func (Nop) MessageAcked(string, string)              {}
+func (Nop) MessageNacked(string, string)             {}
+func (Nop) MessageRetried(string, string)            {}
+func (Nop) MessageDeadLettered(string, string)       {}
+func (Nop) AssignmentObserved(string, time.Duration) {}
+func (Nop) AssignmentScored(float64)                 {}
+func (Nop) NoSuitableUsers(int)                      {}
@@ -0,0 +1,185 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "task_optimizer"
+
+// Prometheus is a Recorder exposing the metrics in the Prometheus format
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	messagesConsumed     *prometheus.CounterVec
+	messagesAcked        *prometheus.CounterVec
+	messagesNacked       *prometheus.CounterVec
+	messagesRetried      *prometheus.CounterVec
+	messagesDeadLettered *prometheus.CounterVec
+	assignmentDuration   *prometheus.HistogramVec
+	assignmentScore      prometheus.Histogram
+	noSuitableUsers      *prometheus.CounterVec
+}
+
+var _ Recorder = (*Prometheus)(nil)
+
+// NewPrometheus creates the metrics in a new registry, along with the Go runtime and process metrics
+func NewPrometheus() *Prometheus {
+	messageLabels := []string{"queue", "routing_key"}
+
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		messagesConsumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_consumed_total",
+			Help:      "Deliveries taken from the queues.",
+		}, messageLabels),
+		messagesAcked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_acked_total",
+			Help:      "Deliveries handled successfully and acknowledged.",
+		}, messageLabels),
+		messagesNacked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_nacked_total",
+			Help:      "Deliveries returned to their queue because they could not be forwarded.",
+		}, messageLabels),
+		messagesRetried: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_retried_total",
+			Help:      "Deliveries moved to a retry queue after a failure.",
+		}, messageLabels),
+		messagesDeadLettered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_dead_lettered_total",
+			Help:      "Deliveries moved to the parking queue.",
+		}, messageLabels),
+		assignmentDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "assignment_duration_seconds",
+			Help:      "Time taken to assign a task, by outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		assignmentScore: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "assignment_score",
+			Help:      "Total score of the chosen assignees.",
+			Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+		}),
+		noSuitableUsers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_suitable_users_total",
+			Help:      "Tasks nobody could take, by project.",
+		}, []string{"project_id"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.messagesConsumed,
+		p.messagesAcked,
+		p.messagesNacked,
+		p.messagesRetried,
+		p.messagesDeadLettered,
+		p.assignmentDuration,
+		p.assignmentScore,
+		p.noSuitableUsers,
+	)
+
+	return p
+}
+
+// Handler serves the metrics for scraping. A metric that cannot be collected is left out
+// of the scrape instead of failing it.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// BookedTasksSource counts the open tasks booked on each user
+type BookedTasksSource interface {
+	BookedTasksByUser(ctx context.Context) (map[int]int, error)
+}
+
+// bookedTasksTimeout bounds the query run on every scrape
+const bookedTasksTimeout = 5 * time.Second
+
+// RegisterBookedTasks exposes the tasks booked per user, read from the source on every scrape.
+// Reading the booked tasks instead of counting assignments keeps the gauge right across
+// restarts, several instances and tasks assigned outside this service.
+func (p *Prometheus) RegisterBookedTasks(source BookedTasksSource) {
+	p.registry.MustRegister(&bookedTasksCollector{
+		source: source,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "user_assigned_tasks"),
+			"Open tasks booked on the user in the load ledger.",
+			[]string{"user_id"},
+			nil,
+		),
+	})
+}
+
+// bookedTasksCollector reports the booked tasks of every user when collected
+type bookedTasksCollector struct {
+	source BookedTasksSource
+	desc   *prometheus.Desc
+}
+
+// Describe implements prometheus.Collector
+func (c *bookedTasksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *bookedTasksCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookedTasksTimeout)
+	defer cancel()
+
+	booked, err := c.source.BookedTasksByUser(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	for userID, tasks := range booked {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(tasks), strconv.Itoa(userID))
+	}
+}
+
+func (p *Prometheus) MessageConsumed(queue, routingKey string) {
+	p.messagesConsumed.WithLabelValues(queue, routingKey).Inc()
+}
+
+func (p *Prometheus) MessageAcked(queue, routingKey string) {
+	p.messagesAcked.WithLabelValues(queue, routingKey).Inc()
+}
+
+func (p *Prometheus) MessageNacked(queue, routingKey string) {
+	p.messagesNacked.WithLabelValues(queue, routingKey).Inc()
+}
+
+func (p *Prometheus) MessageRetried(queue, routingKey string) {
+	p.messagesRetried.WithLabelValues(queue, routingKey).Inc()
+}
+
+func (p *Prometheus) MessageDeadLettered(queue, routingKey string) {
+	p.messagesDeadLettered.WithLabelValues(queue, routingKey).Inc()
+}
+
+func (p *Prometheus) AssignmentObserved(outcome string, duration time.Duration) {
+	p.assignmentDuration.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) AssignmentScored(score float64) {
+	p.assignmentScore.Observe(score)
+}
+
+func (p *Prometheus) NoSuitableUsers(projectID int) {
+	p.noSuitableUsers.WithLabelValues(strconv.Itoa(projectID)).Inc()
+}
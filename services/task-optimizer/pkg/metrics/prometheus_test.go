@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bookedTasks is a BookedTasksSource returning fixed counts
+type bookedTasks struct {
+	booked map[int]int
+	err    error
+}
+
+func (b bookedTasks) BookedTasksByUser(context.Context) (map[int]int, error) {
+	return b.booked, b.err
+}
+
+func TestPrometheusBookedTasks(t *testing.T) {
+	t.Run("reports the tasks booked per user", func(t *testing.T) {
+		p := NewPrometheus()
+		p.RegisterBookedTasks(bookedTasks{booked: map[int]int{3: 2, 7: 1}})
+
+		expected := `
+# HELP task_optimizer_user_assigned_tasks Open tasks booked on the user in the load ledger.
+# TYPE task_optimizer_user_assigned_tasks gauge
+task_optimizer_user_assigned_tasks{user_id="3"} 2
+task_optimizer_user_assigned_tasks{user_id="7"} 1
+`
+		require.NoError(t, testutil.GatherAndCompare(p.registry, strings.NewReader(expected), "task_optimizer_user_assigned_tasks"))
+	})
+
+	t.Run("failed query leaves the other metrics scrapable", func(t *testing.T) {
+		p := NewPrometheus()
+		p.RegisterBookedTasks(bookedTasks{err: errors.New("connection reset")})
+		p.AssignmentScored(0.8)
+
+		recorder := httptest.NewRecorder()
+		p.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "task_optimizer_assignment_score_count 1")
+		assert.NotContains(t, recorder.Body.String(), "task_optimizer_user_assigned_tasks{")
+	})
+}
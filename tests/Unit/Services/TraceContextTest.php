@@ -0,0 +1,56 @@
+<?php
+
+namespace Tests\Unit\Services;
+
+use App\Services\TraceContext;
+use Tests\TestCase;
+
+class TraceContextTest extends TestCase
+{
+    private const TRACEPARENT_PATTERN = '/^00-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$/';
+
+    public function test_starts_a_new_sampled_trace_without_incoming_context(): void
+    {
+        $traceparent = TraceContext::traceparent();
+
+        $this->assertMatchesRegularExpression(self::TRACEPARENT_PATTERN, $traceparent);
+        $this->assertStringEndsWith('-01', $traceparent);
+        $this->assertNotSame(substr($traceparent, 3, 32), substr(TraceContext::traceparent(), 3, 32));
+    }
+
+    public function test_continues_the_incoming_trace_with_a_new_parent(): void
+    {
+        $incoming = '00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00';
+
+        $traceparent = TraceContext::traceparent($incoming);
+
+        $this->assertMatchesRegularExpression(self::TRACEPARENT_PATTERN, $traceparent);
+        $this->assertStringStartsWith('00-4bf92f3577b34da6a3ce929d0e0e4736-', $traceparent);
+        $this->assertStringEndsWith('-00', $traceparent);
+        $this->assertNotSame($incoming, $traceparent);
+    }
+
+    public function test_ignores_an_invalid_incoming_traceparent(): void
+    {
+        $invalid = [
+            'garbage',
+            '00-00000000000000000000000000000000-00f067aa0ba902b7-01',
+            '00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01',
+        ];
+
+        foreach ($invalid as $incoming) {
+            $traceparent = TraceContext::traceparent($incoming);
+
+            $this->assertMatchesRegularExpression(self::TRACEPARENT_PATTERN, $traceparent);
+            $this->assertStringNotContainsString('4bf92f3577b34da6a3ce929d0e0e4736', $traceparent);
+            $this->assertStringNotContainsString('00000000000000000000000000000000', $traceparent);
+        }
+    }
+
+    public function test_continues_the_trace_of_the_current_request(): void
+    {
+        request()->headers->set('traceparent', '00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01');
+
+        $this->assertStringStartsWith('00-4bf92f3577b34da6a3ce929d0e0e4736-', TraceContext::fromRequest());
+    }
+}